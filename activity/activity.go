@@ -2,6 +2,8 @@ package activity
 
 import (
 	"context"
+	"sort"
+	"time"
 
 	"github.com/Jkenyut/nvx-go-helper/cryptoutil"
 )
@@ -17,6 +19,12 @@ const (
 	Payload                  // Request payload
 	Result                   // Response result
 	RequestIDKey             // Request ID for tracing
+	StartTime                // Request start time for latency
+	UserID                   // Authenticated user identifier
+	ClientIP                 // Caller IP address
+	Flags                    // Per-request feature flags
+
+	numKeys // sentinel: number of keys above, keep last
 )
 
 // NewContext creates a new context with a generated transaction ID and action.
@@ -92,6 +100,125 @@ func GetRequestID(ctx context.Context) (string, bool) {
 	return requestID, ok
 }
 
+// WithUserID adds the authenticated user's ID to the context.
+// Set it in auth middleware once the token is verified.
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, UserID, userID)
+}
+
+// GetUserID retrieves the authenticated user's ID from the context.
+func GetUserID(ctx context.Context) (string, bool) {
+	userID, ok := ctx.Value(UserID).(string)
+	return userID, ok
+}
+
+// WithClientIP adds the caller's IP address to the context.
+// Resolve it once in middleware (honoring trusted proxies) and store the result.
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, ClientIP, ip)
+}
+
+// GetClientIP retrieves the caller's IP address from the context.
+func GetClientIP(ctx context.Context) (string, bool) {
+	ip, ok := ctx.Value(ClientIP).(string)
+	return ip, ok
+}
+
+// WithFlags stores the feature flags resolved for this request, replacing any
+// flags already set. The map is copied, so later changes by the caller do not
+// leak into the context.
+//
+// Example:
+//
+//	ctx = activity.WithFlags(ctx, map[string]bool{"new_checkout": true})
+func WithFlags(ctx context.Context, flags map[string]bool) context.Context {
+	copied := make(map[string]bool, len(flags))
+	for name, enabled := range flags {
+		copied[name] = enabled
+	}
+	return context.WithValue(ctx, Flags, copied)
+}
+
+// GetFlag returns the value of feature flag name and whether it was set.
+// An unset flag returns false, false so callers can apply their own default.
+//
+// Example:
+//
+//	if enabled, _ := activity.GetFlag(ctx, "new_checkout"); enabled {
+//	    return newCheckout(ctx, cart)
+//	}
+func GetFlag(ctx context.Context, name string) (bool, bool) {
+	flags, _ := ctx.Value(Flags).(map[string]bool)
+	enabled, ok := flags[name]
+	return enabled, ok
+}
+
+// activeFlags returns the sorted names of enabled flags in ctx, or nil if none.
+func activeFlags(ctx context.Context) []string {
+	flags, _ := ctx.Value(Flags).(map[string]bool)
+	var names []string
+	for name, enabled := range flags {
+		if enabled {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CorrelationID returns the ID to correlate logs with, by precedence:
+// 1. Request ID (set by middleware from the X-Request-ID header)
+// 2. Transaction ID (set by NewContext)
+// Returns empty string if neither is present.
+//
+// Example:
+//
+//	logger.Info("payment processed", "correlation_id", activity.CorrelationID(ctx))
+func CorrelationID(ctx context.Context) string {
+	if requestID, ok := GetRequestID(ctx); ok && requestID != "" {
+		return requestID
+	}
+	if trxID, ok := GetTransactionID(ctx); ok {
+		return trxID
+	}
+	return ""
+}
+
+// WithStartTime records when request handling began.
+// Set it in middleware at request entry; response.NewMeta then reports duration_ms.
+//
+// Example:
+//
+//	ctx := activity.WithStartTime(r.Context(), time.Now())
+func WithStartTime(ctx context.Context, start time.Time) context.Context {
+	return context.WithValue(ctx, StartTime, start)
+}
+
+// GetStartTime retrieves the request start time from the context.
+func GetStartTime(ctx context.Context) (time.Time, bool) {
+	start, ok := ctx.Value(StartTime).(time.Time)
+	return start, ok
+}
+
+// Detach returns a new context rooted at context.Background() that carries a copy
+// of every activity field from ctx but none of its cancellation or deadline.
+// Use it for fire-and-forget goroutines (e.g. sending an email after the handler
+// returns) so their logs keep the same transaction/request IDs.
+//
+// Example:
+//
+//	go sendWelcomeEmail(activity.Detach(ctx), user)
+func Detach(ctx context.Context) context.Context {
+	detached := context.Background()
+	// Copy every registered key that has a value
+	for k := key(0); k < numKeys; k++ {
+		if v := ctx.Value(k); v != nil {
+			detached = context.WithValue(detached, k, v)
+		}
+	}
+	return detached
+}
+
 // GetFields collects all activity-related fields from the context into a map.
 // Useful for structured logging.
 func GetFields(ctx context.Context) map[string]interface{} {
@@ -117,9 +244,40 @@ func GetFields(ctx context.Context) map[string]interface{} {
 		fields["client_id"] = clientID
 	}
 
+	// Add user_id if present
+	if userID, ok := GetUserID(ctx); ok {
+		fields["user_id"] = userID
+	}
+
+	// Add client_ip if present
+	if ip, ok := GetClientIP(ctx); ok {
+		fields["client_ip"] = ip
+	}
+
+	// Add enabled flag names if any
+	if names := activeFlags(ctx); len(names) > 0 {
+		fields["flags"] = names
+	}
+
 	// Add payload and result (can be nil)
 	fields["payload"] = GetPayload(ctx)
 	fields["result"] = GetResult(ctx)
 
 	return fields
 }
+
+// MergeFields returns GetFields(ctx) overlaid with extra; extra wins on key conflict.
+// Neither input is mutated, so a shared extra map can be reused across requests.
+//
+// Example:
+//
+//	fields := activity.MergeFields(ctx, map[string]interface{}{"order_id": orderID})
+//	logger.WithFields(fields).Info("order created")
+func MergeFields(ctx context.Context, extra map[string]interface{}) map[string]interface{} {
+	// GetFields always builds a fresh map
+	fields := GetFields(ctx)
+	for k, v := range extra {
+		fields[k] = v
+	}
+	return fields
+}
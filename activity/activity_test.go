@@ -3,6 +3,7 @@ package activity
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -79,6 +80,8 @@ func TestGetFields(t *testing.T) {
 		ctx = WithRequestID(ctx, "req-001")
 		ctx = WithPayload(ctx, "test-payload")
 		ctx = WithResult(ctx, "test-result")
+		ctx = WithUserID(ctx, "user-001")
+		ctx = WithClientIP(ctx, "10.0.0.1")
 
 		fields := GetFields(ctx)
 
@@ -88,6 +91,8 @@ func TestGetFields(t *testing.T) {
 		assert.Equal(t, "req-001", fields["request_id"])
 		assert.Equal(t, "test-payload", fields["payload"])
 		assert.Equal(t, "test-result", fields["result"])
+		assert.Equal(t, "user-001", fields["user_id"])
+		assert.Equal(t, "10.0.0.1", fields["client_ip"])
 	})
 
 	t.Run("Empty context", func(t *testing.T) {
@@ -101,3 +106,102 @@ func TestGetFields(t *testing.T) {
 		assert.Nil(t, fields["action"])
 	})
 }
+
+func TestFlags(t *testing.T) {
+	flags := map[string]bool{"new_checkout": true, "dark_mode": false, "beta_search": true}
+	ctx := WithFlags(context.Background(), flags)
+
+	enabled, ok := GetFlag(ctx, "new_checkout")
+	assert.True(t, enabled)
+	assert.True(t, ok)
+
+	enabled, ok = GetFlag(ctx, "dark_mode")
+	assert.False(t, enabled)
+	assert.True(t, ok)
+
+	enabled, ok = GetFlag(ctx, "unknown")
+	assert.False(t, enabled)
+	assert.False(t, ok)
+
+	// Stored map is a copy
+	flags["dark_mode"] = true
+	enabled, _ = GetFlag(ctx, "dark_mode")
+	assert.False(t, enabled)
+
+	// Only enabled flags are logged, sorted
+	assert.Equal(t, []string{"beta_search", "new_checkout"}, GetFields(ctx)["flags"])
+	assert.NotContains(t, GetFields(WithFlags(context.Background(), nil)), "flags")
+
+	// No flags at all
+	_, ok = GetFlag(context.Background(), "new_checkout")
+	assert.False(t, ok)
+}
+
+func TestCorrelationID(t *testing.T) {
+	assert.Equal(t, "", CorrelationID(context.Background()))
+
+	ctx := NewContext("checkout")
+	trxID, _ := GetTransactionID(ctx)
+	assert.Equal(t, trxID, CorrelationID(ctx))
+
+	// Empty request ID falls back to transaction ID
+	assert.Equal(t, trxID, CorrelationID(WithRequestID(ctx, "")))
+
+	assert.Equal(t, "req-123", CorrelationID(WithRequestID(ctx, "req-123")))
+}
+
+func TestStartTime(t *testing.T) {
+	ctx := context.Background()
+
+	_, ok := GetStartTime(ctx)
+	assert.False(t, ok)
+
+	start := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	got, ok := GetStartTime(WithStartTime(ctx, start))
+	assert.True(t, ok)
+	assert.Equal(t, start, got)
+}
+
+func TestDetach(t *testing.T) {
+	ctx := NewContext("send_email")
+	ctx = WithClientID(ctx, "client-001")
+	ctx = WithRequestID(ctx, "req-001")
+	ctx = WithPayload(ctx, "payload")
+	ctx = WithResult(ctx, "result")
+	ctx = WithUserID(ctx, "user-001")
+	ctx = WithClientIP(ctx, "10.0.0.1")
+
+	parent, cancel := context.WithTimeout(ctx, time.Minute)
+	cancel() // parent is already cancelled
+
+	detached := Detach(parent)
+
+	assert.NoError(t, detached.Err())
+	_, hasDeadline := detached.Deadline()
+	assert.False(t, hasDeadline)
+	assert.Nil(t, detached.Done())
+
+	// Every field is carried over
+	assert.Equal(t, GetFields(ctx), GetFields(detached))
+}
+
+func TestMergeFields(t *testing.T) {
+	ctx := WithRequestID(NewContext("checkout"), "req-001")
+	extra := map[string]interface{}{"order_id": 42, "action": "override"}
+
+	merged := MergeFields(ctx, extra)
+
+	assert.Equal(t, "req-001", merged["request_id"])
+	assert.Equal(t, 42, merged["order_id"])
+	assert.Equal(t, "override", merged["action"]) // extra wins
+
+	// Inputs are untouched
+	action, _ := GetAction(ctx)
+	assert.Equal(t, "checkout", action)
+	assert.Len(t, extra, 2)
+	merged["new"] = true
+	assert.NotContains(t, extra, "new")
+
+	// Nil extra returns the context fields
+	assert.Equal(t, GetFields(ctx), MergeFields(ctx, nil))
+}
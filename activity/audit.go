@@ -0,0 +1,51 @@
+package activity
+
+import (
+	"context"
+	"time"
+)
+
+// AuditEntry is one audit-log record for a mutating request: who did what,
+// from where, and how it ended. Every service emits the same shape, so the
+// SIEM can parse all of them with one schema. Empty fields are still present
+// in JSON to keep the shape fixed.
+type AuditEntry struct {
+	Timestamp     time.Time `json:"timestamp"`            // when the entry was built, UTC
+	Action        string    `json:"action"`               // e.g. "user.delete"
+	UserID        string    `json:"user_id"`              // authenticated user
+	ClientID      string    `json:"client_id"`            // calling client/application
+	ClientIP      string    `json:"client_ip"`            // caller IP address
+	RequestID     string    `json:"request_id"`           // correlation ID for tracing
+	TransactionID string    `json:"transaction_id"`       // internal transaction ID
+	StatusCode    int       `json:"status_code"`          // HTTP status of the outcome
+	Success       bool      `json:"success"`              // true for 2xx
+	ErrorCode     string    `json:"error_code,omitempty"` // machine-readable failure code
+}
+
+// NewAuditEntry builds an AuditEntry from the activity fields in ctx and the
+// request outcome. An empty action falls back to the action stored in ctx.
+// Use response.AuditEntry to fill the outcome from a Response.
+//
+// Example:
+//
+//	entry := activity.NewAuditEntry(ctx, "user.delete", http.StatusNoContent, true)
+//	auditLogger.Info("audit", "entry", entry)
+func NewAuditEntry(ctx context.Context, action string, statusCode int, success bool) AuditEntry {
+	if action == "" {
+		action, _ = GetAction(ctx)
+	}
+
+	entry := AuditEntry{
+		Timestamp:  time.Now().UTC(),
+		Action:     action,
+		StatusCode: statusCode,
+		Success:    success,
+	}
+	// Missing fields stay empty
+	entry.UserID, _ = GetUserID(ctx)
+	entry.ClientID, _ = GetClientID(ctx)
+	entry.ClientIP, _ = GetClientIP(ctx)
+	entry.RequestID, _ = GetRequestID(ctx)
+	entry.TransactionID, _ = GetTransactionID(ctx)
+	return entry
+}
@@ -0,0 +1,47 @@
+package activity
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAuditEntry(t *testing.T) {
+	ctx := NewContext("user.update")
+	ctx = WithRequestID(ctx, "req-001")
+	ctx = WithUserID(ctx, "user-42")
+	ctx = WithClientID(ctx, "mobile-app")
+	ctx = WithClientIP(ctx, "203.0.113.7")
+
+	entry := NewAuditEntry(ctx, "user.delete", 204, true)
+
+	assert.Equal(t, "user.delete", entry.Action)
+	assert.Equal(t, "user-42", entry.UserID)
+	assert.Equal(t, "mobile-app", entry.ClientID)
+	assert.Equal(t, "203.0.113.7", entry.ClientIP)
+	assert.Equal(t, "req-001", entry.RequestID)
+	assert.NotEmpty(t, entry.TransactionID)
+	assert.Equal(t, 204, entry.StatusCode)
+	assert.True(t, entry.Success)
+	assert.Equal(t, time.UTC, entry.Timestamp.Location())
+	assert.WithinDuration(t, time.Now(), entry.Timestamp, time.Second)
+
+	// Empty action falls back to the context action
+	assert.Equal(t, "user.update", NewAuditEntry(ctx, "", 200, true).Action)
+}
+
+func TestAuditEntry_JSONShape(t *testing.T) {
+	// Missing fields are still present so every record has the same shape
+	b, err := json.Marshal(NewAuditEntry(context.Background(), "login", 401, false))
+	assert.NoError(t, err)
+
+	var decoded map[string]any
+	assert.NoError(t, json.Unmarshal(b, &decoded))
+	for _, key := range []string{"timestamp", "action", "user_id", "client_id", "client_ip", "request_id", "transaction_id", "status_code", "success"} {
+		assert.Contains(t, decoded, key)
+	}
+	assert.NotContains(t, decoded, "error_code")
+}
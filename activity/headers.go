@@ -0,0 +1,29 @@
+package activity
+
+import (
+	"context"
+	"net/http"
+)
+
+// RequestIDHeader is the outgoing header InjectHeaders uses to propagate the
+// correlation ID. Defaults to "X-Request-ID" to match response.RequestIDHeader;
+// change both together, once at startup.
+var RequestIDHeader = "X-Request-ID"
+
+// InjectHeaders propagates ctx's tracing fields onto the headers of an outgoing
+// request, so the downstream service logs under the same ID. It sets
+// RequestIDHeader to CorrelationID(ctx) unless h already carries one; a caller's
+// explicit value always wins. Does nothing if ctx has no ID or the header is "".
+//
+// Example:
+//
+//	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+//	activity.InjectHeaders(ctx, req.Header)
+func InjectHeaders(ctx context.Context, h http.Header) {
+	if RequestIDHeader == "" || h.Get(RequestIDHeader) != "" {
+		return
+	}
+	if id := CorrelationID(ctx); id != "" {
+		h.Set(RequestIDHeader, id)
+	}
+}
@@ -0,0 +1,54 @@
+package activity
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInjectHeaders(t *testing.T) {
+	t.Run("Request ID wins over transaction ID", func(t *testing.T) {
+		ctx := WithRequestID(NewContext("call"), "req-123")
+		h := http.Header{}
+		InjectHeaders(ctx, h)
+		assert.Equal(t, "req-123", h.Get("X-Request-ID"))
+	})
+
+	t.Run("Falls back to transaction ID", func(t *testing.T) {
+		ctx := NewContext("job")
+		trxID, _ := GetTransactionID(ctx)
+		h := http.Header{}
+		InjectHeaders(ctx, h)
+		assert.Equal(t, trxID, h.Get("X-Request-ID"))
+	})
+
+	t.Run("Existing header is kept", func(t *testing.T) {
+		h := http.Header{}
+		h.Set("X-Request-ID", "caller")
+		InjectHeaders(WithRequestID(context.Background(), "req-123"), h)
+		assert.Equal(t, "caller", h.Get("X-Request-ID"))
+	})
+
+	t.Run("No ID, no header", func(t *testing.T) {
+		h := http.Header{}
+		InjectHeaders(context.Background(), h)
+		assert.Empty(t, h)
+	})
+
+	t.Run("Custom and disabled header", func(t *testing.T) {
+		defer func(old string) { RequestIDHeader = old }(RequestIDHeader)
+		ctx := WithRequestID(context.Background(), "req-123")
+
+		RequestIDHeader = "X-Correlation-ID"
+		h := http.Header{}
+		InjectHeaders(ctx, h)
+		assert.Equal(t, "req-123", h.Get("X-Correlation-ID"))
+
+		RequestIDHeader = ""
+		h = http.Header{}
+		InjectHeaders(ctx, h)
+		assert.Empty(t, h)
+	})
+}
@@ -0,0 +1,153 @@
+// Package cache provides an in-process cache-aside helper with TTL and
+// single-flight, for expensive lookups shared across requests.
+//
+// Entries live in one process-wide map; use a shared cache (Redis, etc.)
+// when values must be consistent across replicas.
+//
+// Example:
+//
+//	user, err := cache.GetOrCompute(ctx, "user:"+id, 5*time.Minute, func(ctx context.Context) (User, error) {
+//	    return repo.FindUser(ctx, id)
+//	})
+package cache
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// sweepInterval bounds how often expired entries are purged on write.
+const sweepInterval = time.Minute
+
+type entry struct {
+	value     any
+	expiresAt time.Time
+}
+
+// call is an in-flight computation shared by concurrent misses.
+type call struct {
+	done  chan struct{}
+	value any
+	err   error
+}
+
+var (
+	mu        sync.Mutex
+	entries   = make(map[string]entry)
+	inFlight  = make(map[string]*call)
+	nextSweep time.Time
+)
+
+// GetOrCompute returns the cached value for key, or runs compute, stores its
+// result for ttl, and returns it. Concurrent misses for the same key run compute
+// only once; the others wait for its result.
+//
+// Behavior:
+//   - Errors are returned to every waiter but never cached; a panic in compute
+//     is returned as an error.
+//   - compute runs with ctx's values but without its cancellation, so one caller
+//     disconnecting does not fail the others. Each caller still stops waiting
+//     (returning ctx.Err()) when its own ctx is done.
+//   - Keys are global: prefix them per use ("user:42") so different types never share a key.
+//     A cached value of another type is treated as a miss and replaced.
+//   - ttl <= 0 computes (single-flight) without storing the result.
+func GetOrCompute[T any](ctx context.Context, key string, ttl time.Duration, compute func(context.Context) (T, error)) (T, error) {
+	var zero T
+
+	mu.Lock()
+	// Cache hit
+	if e, ok := entries[key]; ok && time.Now().Before(e.expiresAt) {
+		if v, ok := as[T](e.value); ok {
+			mu.Unlock()
+			return v, nil
+		}
+	}
+
+	// Join an in-flight computation, or start one
+	c, running := inFlight[key]
+	if !running {
+		c = &call{done: make(chan struct{})}
+		inFlight[key] = c
+		go run(context.WithoutCancel(ctx), key, ttl, c, func(ctx context.Context) (any, error) {
+			return compute(ctx)
+		})
+	}
+	mu.Unlock()
+
+	select {
+	case <-c.done:
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+
+	if c.err != nil {
+		return zero, c.err
+	}
+	v, ok := as[T](c.value)
+	if !ok {
+		// Another type computed under the same key; compute our own
+		return compute(ctx)
+	}
+	return v, nil
+}
+
+// as converts a stored value back to T. A nil value is what an interface-typed
+// T (e.g. any or error) stores for a nil result, so it is a hit for such T;
+// plain assertion would reject it and turn every lookup into a recompute.
+func as[T any](value any) (T, bool) {
+	if value == nil {
+		var zero T
+		return zero, reflect.TypeFor[T]().Kind() == reflect.Interface
+	}
+	v, ok := value.(T)
+	return v, ok
+}
+
+// run executes compute for key, stores a successful result, and releases waiters.
+func run(ctx context.Context, key string, ttl time.Duration, c *call, compute func(context.Context) (any, error)) {
+	func() {
+		// compute runs on its own goroutine: a panic would crash the process
+		// and leave waiters blocked forever
+		defer func() {
+			if r := recover(); r != nil {
+				c.err = fmt.Errorf("cache: compute for %q panicked: %v", key, r)
+			}
+		}()
+		c.value, c.err = compute(ctx)
+	}()
+
+	mu.Lock()
+	delete(inFlight, key)
+	if c.err == nil && ttl > 0 {
+		now := time.Now()
+		sweep(now)
+		entries[key] = entry{value: c.value, expiresAt: now.Add(ttl)}
+	}
+	mu.Unlock()
+
+	close(c.done)
+}
+
+// sweep removes expired entries at most once per sweepInterval. Caller holds mu.
+func sweep(now time.Time) {
+	if now.Before(nextSweep) {
+		return
+	}
+	for k, e := range entries {
+		if !now.Before(e.expiresAt) {
+			delete(entries, k)
+		}
+	}
+	nextSweep = now.Add(sweepInterval)
+}
+
+// Invalidate removes key so the next GetOrCompute recomputes it.
+// A computation already in flight still completes and stores its result.
+func Invalidate(key string) {
+	mu.Lock()
+	delete(entries, key)
+	mu.Unlock()
+}
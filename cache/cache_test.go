@@ -0,0 +1,161 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// keyCounter makes every test run use fresh keys: entries is process-wide,
+// so a reused key would hit values cached by an earlier run (go test -count=2)
+var keyCounter atomic.Int64
+
+// testKey returns prefix with a suffix unique to this process.
+func testKey(prefix string) string {
+	return fmt.Sprintf("%s:%d", prefix, keyCounter.Add(1))
+}
+
+func TestGetOrCompute(t *testing.T) {
+	key := testKey("test:hit")
+	ctx := context.Background()
+	var calls int32
+	compute := func(ctx context.Context) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		return "budi", nil
+	}
+
+	v, err := GetOrCompute(ctx, key, time.Minute, compute)
+	assert.NoError(t, err)
+	assert.Equal(t, "budi", v)
+
+	v, err = GetOrCompute(ctx, key, time.Minute, compute)
+	assert.NoError(t, err)
+	assert.Equal(t, "budi", v)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// Invalidate forces a recompute
+	Invalidate(key)
+	_, _ = GetOrCompute(ctx, key, time.Minute, compute)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestGetOrCompute_TTL(t *testing.T) {
+	key := testKey("test:ttl")
+	ctx := context.Background()
+	var calls int32
+	compute := func(ctx context.Context) (int, error) {
+		return int(atomic.AddInt32(&calls, 1)), nil
+	}
+
+	v, _ := GetOrCompute(ctx, key, 20*time.Millisecond, compute)
+	assert.Equal(t, 1, v)
+	time.Sleep(30 * time.Millisecond)
+	v, _ = GetOrCompute(ctx, key, 20*time.Millisecond, compute)
+	assert.Equal(t, 2, v)
+}
+
+func TestGetOrCompute_SingleFlight(t *testing.T) {
+	key := testKey("test:flight")
+	ctx := context.Background()
+	var calls int32
+	release := make(chan struct{})
+	compute := func(ctx context.Context) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return 42, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := GetOrCompute(ctx, key, time.Minute, compute)
+			assert.NoError(t, err)
+			assert.Equal(t, 42, v)
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestGetOrCompute_ErrorsNotCached(t *testing.T) {
+	key := testKey("test:err")
+	ctx := context.Background()
+	errDB := errors.New("db down")
+	var calls int32
+	compute := func(ctx context.Context) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		return 0, errDB
+	}
+
+	_, err := GetOrCompute(ctx, key, time.Minute, compute)
+	assert.ErrorIs(t, err, errDB)
+	_, err = GetOrCompute(ctx, key, time.Minute, compute)
+	assert.ErrorIs(t, err, errDB)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestGetOrCompute_Panic(t *testing.T) {
+	key := testKey("test:panic")
+	_, err := GetOrCompute(context.Background(), key, time.Minute, func(ctx context.Context) (int, error) {
+		panic("boom")
+	})
+	assert.ErrorContains(t, err, "panicked: boom")
+}
+
+func TestGetOrCompute_CallerCancel(t *testing.T) {
+	key := testKey("test:cancel")
+	release := make(chan struct{})
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := GetOrCompute(ctx, key, time.Minute, func(ctx context.Context) (int, error) {
+		<-release
+		return 1, ctx.Err() // compute's ctx is not cancelled with the caller's
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestGetOrCompute_TypeMismatch(t *testing.T) {
+	key := testKey("test:type")
+	ctx := context.Background()
+	_, _ = GetOrCompute(ctx, key, time.Minute, func(ctx context.Context) (int, error) { return 1, nil })
+
+	v, err := GetOrCompute(ctx, key, time.Minute, func(ctx context.Context) (string, error) { return "one", nil })
+	assert.NoError(t, err)
+	assert.Equal(t, "one", v)
+}
+
+func TestGetOrCompute_NilInterface(t *testing.T) {
+	ctx := context.Background()
+	key := testKey("test:nil")
+	var calls int32
+	compute := func(ctx context.Context) (any, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, nil
+	}
+
+	for i := 0; i < 3; i++ {
+		v, err := GetOrCompute(ctx, key, time.Minute, compute)
+		assert.NoError(t, err)
+		assert.Nil(t, v)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// A concrete type still treats the nil entry as a miss
+	s, err := GetOrCompute(ctx, key, time.Minute, func(ctx context.Context) (string, error) { return "one", nil })
+	assert.NoError(t, err)
+	assert.Equal(t, "one", s)
+}
@@ -46,6 +46,18 @@ func NewAESGCM(keys string) (*AESGCM, error) {
 
 // Encrypt any data → URL-safe base64 string (super fast)
 func (c *AESGCM) Encrypt(data any) (string, error) {
+	return c.EncryptAAD(data, nil)
+}
+
+// EncryptAAD is Encrypt with additional authenticated data bound to the ciphertext.
+// aad (e.g. a tenant or user ID) is NOT encrypted or stored in the output: the
+// same aad must be passed to DecryptAAD, otherwise decryption fails. Use it so a
+// record copied into another tenant's row cannot be decrypted there.
+//
+// Example:
+//
+//	token, err := c.EncryptAAD(card, []byte(tenantID))
+func (c *AESGCM) EncryptAAD(data any, aad []byte) (string, error) {
 	// Serialize data to JSON
 	plaintext, err := json.Marshal(data)
 	if err != nil {
@@ -59,15 +71,29 @@ func (c *AESGCM) Encrypt(data any) (string, error) {
 		return "", fmt.Errorf("nonce generation failed: %w", err)
 	}
 
-	// Encrypt and authenticate
+	// Encrypt and authenticate (aad is authenticated but not encrypted)
 	// Seal appends result to the first argument (nonce) for efficiency
-	ciphertext := c.aead.Seal(nonce, nonce, plaintext, nil)
+	ciphertext := c.aead.Seal(nonce, nonce, plaintext, aad)
 	// Return result as URL-safe Base64 string
 	return base64.URLEncoding.EncodeToString(ciphertext), nil
 }
 
 // Decrypt base64 string → original struct/map
 func (c *AESGCM) Decrypt(encrypted string, target any) error {
+	return c.DecryptAAD(encrypted, nil, target)
+}
+
+// DecryptAAD reverses EncryptAAD. It fails with an authentication error when aad
+// differs from the value used to encrypt; GCM cannot tell this apart from a
+// wrong key or tampered data, so all three return the same error.
+//
+// Example:
+//
+//	var card Card
+//	if err := c.DecryptAAD(token, []byte(tenantID), &card); err != nil {
+//	    return err // wrong tenant, wrong key, or tampered
+//	}
+func (c *AESGCM) DecryptAAD(encrypted string, aad []byte, target any) error {
 	// Decode Base64 string
 	data, err := base64.URLEncoding.DecodeString(encrypted)
 	if err != nil {
@@ -83,9 +109,12 @@ func (c *AESGCM) Decrypt(encrypted string, target any) error {
 	nonce := data[:12]
 	ciphertext := data[12:]
 
-	// Decrypt and verify authentication tag
-	plaintext, err := c.aead.Open(nil, nonce, ciphertext, nil)
+	// Decrypt and verify authentication tag (covers aad too)
+	plaintext, err := c.aead.Open(nil, nonce, ciphertext, aad)
 	if err != nil {
+		if len(aad) > 0 {
+			return fmt.Errorf("decryption failed (wrong key, aad mismatch, or tampered): %w", err)
+		}
 		return fmt.Errorf("decryption failed (wrong key or tampered): %w", err)
 	}
 
@@ -46,4 +46,24 @@ func TestAESGCM(t *testing.T) {
 		err := aes.Decrypt("invalid-base64", &target)
 		assert.Error(t, err)
 	})
+
+	t.Run("AAD must match", func(t *testing.T) {
+		original := map[string]string{"card": "4111111111111111"}
+		encrypted, err := aes.EncryptAAD(original, []byte("tenant-a"))
+		assert.NoError(t, err)
+
+		var decrypted map[string]string
+		assert.NoError(t, aes.DecryptAAD(encrypted, []byte("tenant-a"), &decrypted))
+		assert.Equal(t, original, decrypted)
+
+		// Another tenant, or no aad at all, fails authentication
+		err = aes.DecryptAAD(encrypted, []byte("tenant-b"), &decrypted)
+		assert.ErrorContains(t, err, "aad mismatch")
+		assert.Error(t, aes.Decrypt(encrypted, &decrypted))
+
+		// Plain ciphertext does not decrypt with an aad
+		plain, _ := aes.Encrypt(original)
+		assert.Error(t, aes.DecryptAAD(plain, []byte("tenant-a"), &decrypted))
+		assert.NoError(t, aes.DecryptAAD(plain, nil, &decrypted))
+	})
 }
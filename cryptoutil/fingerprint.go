@@ -0,0 +1,73 @@
+package cryptoutil
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// CanonicalJSON encodes v as byte-stable JSON: equal data always yields equal
+// bytes, whether it is held in a struct or the equivalent map.
+//
+// Canonicalization applied:
+//   - v is round-tripped through JSON, so every object becomes a map and keys
+//     are sorted by byte order at every depth.
+//   - No insignificant whitespace and no trailing newline.
+//   - HTML characters (<, >, &) are NOT escaped, unlike json.Marshal.
+//   - Numbers keep the literal produced by encoding/json (no float64 rounding).
+//
+// Returns an error naming the problem if v cannot be serialized
+// (channels, funcs, NaN, cyclic data, failing MarshalJSON).
+//
+// Example:
+//
+//	b, _ := cryptoutil.CanonicalJSON(map[string]int{"b": 2, "a": 1}) // {"a":1,"b":2}
+func CanonicalJSON(v any) ([]byte, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("value is not JSON-serializable: %w", err)
+	}
+
+	// Decode into maps/slices; json.Number keeps numeric literals exact
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var generic any
+	if err := dec.Decode(&generic); err != nil {
+		return nil, fmt.Errorf("value is not JSON-serializable: %w", err)
+	}
+
+	// Maps are encoded with sorted keys
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(generic); err != nil {
+		return nil, err
+	}
+	// Encoder appends a newline; drop it so the bytes are exactly the document
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// Fingerprint returns the SHA-256 hex digest (64 characters) of v's CanonicalJSON,
+// stable across runs and processes for equal data. Use it for ETags, cache keys,
+// or change detection. Not a MAC: anyone can compute it, so never use it to
+// authenticate data (use HMAC with a secret key for that).
+//
+// Example:
+//
+//	etag, err := cryptoutil.Fingerprint(resp.Data)
+//	if err != nil {
+//	    return err
+//	}
+//	if response.HandleETag(w, r, etag) {
+//	    return nil
+//	}
+func Fingerprint(v any) (string, error) {
+	canonical, err := CanonicalJSON(v)
+	if err != nil {
+		return "", fmt.Errorf("fingerprint: %w", err)
+	}
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,72 @@
+package cryptoutil
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	type item struct {
+		Name  string `json:"name"`
+		Price int64  `json:"price"`
+		Note  string `json:"note"`
+	}
+
+	b, err := CanonicalJSON(item{Name: "Nasi <goreng>", Price: 25000, Note: "a&b"})
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"Nasi <goreng>","note":"a&b","price":25000}`, string(b))
+
+	// Large integers keep their exact literal
+	b, err = CanonicalJSON(map[string]int64{"id": 1790123456789012345})
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":1790123456789012345}`, string(b))
+}
+
+func TestFingerprint(t *testing.T) {
+	type order struct {
+		ID    string         `json:"id"`
+		Total int            `json:"total"`
+		Tags  []string       `json:"tags"`
+		Extra map[string]any `json:"extra"`
+	}
+
+	fromStruct, err := Fingerprint(order{ID: "A1", Total: 10, Tags: []string{"x", "y"}, Extra: map[string]any{"b": 2, "a": 1}})
+	require.NoError(t, err)
+	assert.Len(t, fromStruct, 64)
+
+	// Equivalent map, keys in a different order
+	fromMap, err := Fingerprint(map[string]any{
+		"tags":  []string{"x", "y"},
+		"extra": map[string]any{"a": 1, "b": 2},
+		"total": 10,
+		"id":    "A1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, fromStruct, fromMap)
+
+	// Stable across calls
+	again, _ := Fingerprint(map[string]any{"id": "A1", "total": 10, "tags": []string{"x", "y"}, "extra": map[string]any{"b": 2, "a": 1}})
+	assert.Equal(t, fromStruct, again)
+
+	// Any change alters the hash, including slice order
+	changed, _ := Fingerprint(map[string]any{"id": "A1", "total": 10, "tags": []string{"y", "x"}, "extra": map[string]any{"a": 1, "b": 2}})
+	assert.NotEqual(t, fromStruct, changed)
+}
+
+func TestFingerprintUnserializable(t *testing.T) {
+	for name, v := range map[string]any{
+		"chan": make(chan int),
+		"func": func() {},
+		"NaN":  math.NaN(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			fp, err := Fingerprint(v)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "not JSON-serializable")
+			assert.Empty(t, fp)
+		})
+	}
+}
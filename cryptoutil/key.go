@@ -0,0 +1,38 @@
+package cryptoutil
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+)
+
+// GenerateKeyURL generates length cryptographically secure random bytes and
+// returns them encoded with base64.RawURLEncoding (alphabet A-Z a-z 0-9 - _, no padding).
+//
+// When to use which encoding:
+//   - RawURLEncoding (this function) → values embedded in URLs, query strings,
+//     filenames, cookies, or HTTP headers. No escaping is ever required.
+//   - StdEncoding → only when a consumer explicitly expects standard base64.
+//     Its "+", "/" and "=" characters break paths and must be percent-encoded in URLs.
+//
+// The output length is ceil(length*4/3) characters; each character carries 6 bits.
+//
+// Example:
+//
+//	key, err := cryptoutil.GenerateKeyURL(32) // "q3Xv0kZ9-T_mB1..." (43 chars, 256-bit)
+func GenerateKeyURL(length uint32) (string, error) {
+	// Guard clause for invalid length
+	if length == 0 {
+		return "", fmt.Errorf("key length must be greater than 0")
+	}
+
+	// Fill buffer from crypto/rand
+	b := make([]byte, length)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", fmt.Errorf("key generation failed: %w", err)
+	}
+
+	// Encode without padding so the result is safe everywhere
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
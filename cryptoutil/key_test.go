@@ -0,0 +1,33 @@
+package cryptoutil
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateKeyURL(t *testing.T) {
+	t.Run("URL-safe output", func(t *testing.T) {
+		key, err := GenerateKeyURL(32)
+		assert.NoError(t, err)
+		assert.Len(t, key, 43) // ceil(32*4/3) without padding
+		assert.Regexp(t, "^[A-Za-z0-9_-]+$", key)
+
+		raw, err := base64.RawURLEncoding.DecodeString(key)
+		assert.NoError(t, err)
+		assert.Len(t, raw, 32)
+	})
+
+	t.Run("Unique", func(t *testing.T) {
+		k1, _ := GenerateKeyURL(16)
+		k2, _ := GenerateKeyURL(16)
+		assert.NotEqual(t, k1, k2)
+	})
+
+	t.Run("Zero Length", func(t *testing.T) {
+		key, err := GenerateKeyURL(0)
+		assert.Error(t, err)
+		assert.Empty(t, key)
+	})
+}
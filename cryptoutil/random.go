@@ -13,6 +13,7 @@
 //   - Unique filenames
 //   - Temporary API keys
 //   - Captcha/session IDs
+//   - Hex / Crockford base32 tokens
 //
 // All functions use crypto/rand under the hood → cryptographically secure
 // Zero external dependencies.
@@ -28,6 +29,9 @@ package cryptoutil
 
 import (
 	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
 	"math/big"
 )
 
@@ -44,6 +48,12 @@ const (
 
 	// Digits only (OTP, PIN, verification code)
 	numbers = "0123456789"
+
+	// Lowercase hexadecimal (case-insensitive systems)
+	lettersHex = "0123456789abcdef"
+
+	// Crockford base32: no I, L, O, U to avoid misreading
+	lettersBase32 = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
 )
 
 // String generates a cryptographically secure random string of given length.
@@ -79,12 +89,126 @@ func Numbers(length int) string {
 	return stringWithCharset(length, numbers)
 }
 
-// stringWithCharset is the core implementation shared by all string functions.
-// It is intentionally unexported — users should use the semantic helpers above.
-func stringWithCharset(length int, charset string) string {
-	// Guard clause for invalid length
+// Hex generates a random lowercase hexadecimal string of exactly length characters.
+// Each character carries 4 bits of entropy, so 32 characters = 128 bits.
+// Ideal for tokens stored in case-insensitive systems.
+//
+// Example: random.Hex(16) → "9f86d081884c7d65"
+func Hex(length int) string {
+	return stringWithCharset(length, lettersHex)
+}
+
+// Base32 generates a random Crockford base32 string (0-9, A-Z without I, L, O, U).
+// Each character carries 5 bits of entropy, so 26 characters = 130 bits.
+// Ideal for codes read aloud or typed by hand.
+//
+// Example: random.Base32(10) → "7ZK3M9QX2T"
+func Base32(length int) string {
+	return stringWithCharset(length, lettersBase32)
+}
+
+// Error-returning variants.
+//
+// The generators above panic if crypto/rand fails, which is practically
+// impossible on a healthy OS. Use these variants on critical paths that must
+// degrade gracefully (e.g. return 503) instead of crashing the process when the
+// RNG is unavailable, as in some sandboxed or hardened environments.
+
+// StringE is String, returning the crypto/rand error instead of panicking.
+//
+// Example:
+//
+//	code, err := cryptoutil.StringE(8)
+//	if err != nil {
+//	    return response.ServiceUnavailable(ctx, "please retry")
+//	}
+func StringE(length int) (string, error) {
+	return StringFrom(rand.Reader, length, letters)
+}
+
+// StringLowerE is StringLower, returning the crypto/rand error instead of panicking.
+func StringLowerE(length int) (string, error) {
+	return StringFrom(rand.Reader, length, lettersLower)
+}
+
+// StringMixedE is StringMixed, returning the crypto/rand error instead of panicking.
+func StringMixedE(length int) (string, error) {
+	return StringFrom(rand.Reader, length, lettersMixed)
+}
+
+// NumbersE is Numbers, returning the crypto/rand error instead of panicking.
+func NumbersE(length int) (string, error) {
+	return StringFrom(rand.Reader, length, numbers)
+}
+
+// HexE is Hex, returning the crypto/rand error instead of panicking.
+func HexE(length int) (string, error) {
+	return StringFrom(rand.Reader, length, lettersHex)
+}
+
+// Base32E is Base32, returning the crypto/rand error instead of panicking.
+func Base32E(length int) (string, error) {
+	return StringFrom(rand.Reader, length, lettersBase32)
+}
+
+// maxUniqueAttempts bounds GenerateUniqueNumbers. With 6 digits and a few thousand
+// active codes, the chance of 10 consecutive collisions is negligible.
+const maxUniqueAttempts = 10
+
+// ErrUniqueExhausted is returned by GenerateUniqueNumbers when every attempt collided.
+var ErrUniqueExhausted = errors.New("cryptoutil: no unique code found within attempt limit")
+
+// GenerateUniqueNumbers generates a numeric code (like Numbers) that exists reports
+// as unused, regenerating on collision. Gives up after 10 attempts with
+// ErrUniqueExhausted, which usually means the code space is too small for the
+// number of active codes; use a longer length.
+// exists is typically a lookup in the OTP store for the same user; a nil exists
+// is an error, like a non-positive length.
+//
+// Example:
+//
+//	otp, err := cryptoutil.GenerateUniqueNumbers(6, func(code string) bool {
+//	    return store.IsActive(userID, code)
+//	})
+func GenerateUniqueNumbers(length int, exists func(string) bool) (string, error) {
 	if length <= 0 {
-		return ""
+		return "", fmt.Errorf("length must be positive")
+	}
+	if exists == nil {
+		return "", fmt.Errorf("exists must not be nil")
+	}
+	for attempt := 0; attempt < maxUniqueAttempts; attempt++ {
+		code, err := NumbersE(length)
+		if err != nil {
+			return "", err
+		}
+		if !exists(code) {
+			return code, nil
+		}
+	}
+	return "", ErrUniqueExhausted
+}
+
+// StringFrom generates a random string of given length from charset, reading
+// randomness from reader instead of crypto/rand. Selection is unbiased
+// (rejection sampling via math/big), exactly like the public generators.
+//
+// FOR TESTING ONLY: pass a deterministic reader to get reproducible output.
+// Never use it with a non-cryptographic source for real tokens or OTPs —
+// production code should call String, Numbers, etc., which use crypto/rand.Reader.
+// charset must be non-empty ASCII.
+//
+// Example:
+//
+//	seed := bytes.NewReader(bytes.Repeat([]byte{7}, 64))
+//	s, _ := cryptoutil.StringFrom(seed, 6, "0123456789") // same value on every run
+func StringFrom(reader io.Reader, length int, charset string) (string, error) {
+	// Guard clauses for invalid input
+	if length <= 0 {
+		return "", nil
+	}
+	if charset == "" {
+		return "", fmt.Errorf("charset must not be empty")
 	}
 	// Create byte slice of requested length
 	b := make([]byte, length)
@@ -93,15 +217,25 @@ func stringWithCharset(length int, charset string) string {
 
 	// Iterate to fill each byte
 	for i := range b {
-		// Generate cryptographically secure random index
-		n, err := rand.Int(rand.Reader, maxID)
+		// Generate uniformly distributed index from the given source
+		n, err := rand.Int(reader, maxID)
 		if err != nil {
-			// Panic is acceptable here as crypto/rand failure is catastrophic
-			panic("crypto/rand.Int failed: " + err.Error())
+			return "", fmt.Errorf("random source failed: %w", err)
 		}
 		// Select character from charset using random index
 		b[i] = charset[n.Int64()]
 	}
 	// Convert byte slice to string and return
-	return string(b)
+	return string(b), nil
+}
+
+// stringWithCharset is the core implementation shared by all string functions.
+// It is intentionally unexported — users should use the semantic helpers above.
+func stringWithCharset(length int, charset string) string {
+	s, err := StringFrom(rand.Reader, length, charset)
+	if err != nil {
+		// Panic is acceptable here as crypto/rand failure is catastrophic
+		panic("crypto/rand.Int failed: " + err.Error())
+	}
+	return s
 }
@@ -1,6 +1,10 @@
 package cryptoutil
 
 import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
 	"strings"
 	"testing"
 
@@ -32,6 +36,20 @@ func TestRandomStrings(t *testing.T) {
 		assert.Regexp(t, "^[a-zA-Z0-9]+$", s)
 	})
 
+	t.Run("Hex (Lowercase hex)", func(t *testing.T) {
+		l := 33 // odd lengths are exact too
+		s := Hex(l)
+		assert.Len(t, s, l)
+		assert.Regexp(t, "^[0-9a-f]+$", s)
+	})
+
+	t.Run("Base32 (Crockford)", func(t *testing.T) {
+		l := 26
+		s := Base32(l)
+		assert.Len(t, s, l)
+		assert.Regexp(t, "^[0-9A-HJKMNP-TV-Z]+$", s)
+	})
+
 	t.Run("Numbers (Digits only)", func(t *testing.T) {
 		l := 6
 		s := Numbers(l)
@@ -68,3 +86,108 @@ func TestStringWithCharset(t *testing.T) {
 		assert.True(t, strings.ContainsRune(charset, r), "Character %c not in charset", r)
 	}
 }
+
+func TestStringFrom(t *testing.T) {
+	seed := func() io.Reader { return bytes.NewReader(bytes.Repeat([]byte{1, 2, 3, 250}, 64)) }
+
+	t.Run("Deterministic", func(t *testing.T) {
+		s1, err := StringFrom(seed(), 8, numbers)
+		assert.NoError(t, err)
+		s2, err := StringFrom(seed(), 8, numbers)
+		assert.NoError(t, err)
+
+		assert.Len(t, s1, 8)
+		assert.Equal(t, s1, s2)
+		assert.Regexp(t, "^[0-9]+$", s1)
+	})
+
+	t.Run("Exhausted Reader", func(t *testing.T) {
+		_, err := StringFrom(bytes.NewReader(nil), 4, letters)
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid Input", func(t *testing.T) {
+		s, err := StringFrom(seed(), 0, letters)
+		assert.NoError(t, err)
+		assert.Empty(t, s)
+
+		_, err = StringFrom(seed(), 4, "")
+		assert.Error(t, err)
+	})
+}
+
+// failingReader simulates an unavailable RNG.
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("rng unavailable") }
+
+func TestErrorVariants(t *testing.T) {
+	generators := map[string]func(int) (string, error){
+		"StringE":       StringE,
+		"StringLowerE":  StringLowerE,
+		"StringMixedE":  StringMixedE,
+		"NumbersE":      NumbersE,
+		"HexE":          HexE,
+		"Base32E":       Base32E,
+		"ReferralCodeE": ReferralCodeE,
+	}
+
+	t.Run("Healthy RNG", func(t *testing.T) {
+		for name, gen := range generators {
+			s, err := gen(8)
+			assert.NoError(t, err, name)
+			assert.GreaterOrEqual(t, len(s), 8, name)
+		}
+	})
+
+	t.Run("Failing RNG returns error", func(t *testing.T) {
+		original := rand.Reader
+		rand.Reader = failingReader{}
+		defer func() { rand.Reader = original }()
+
+		for name, gen := range generators {
+			s, err := gen(8)
+			assert.ErrorContains(t, err, "rng unavailable", name)
+			assert.Empty(t, s, name)
+		}
+
+		_, err := GenerateUniqueNumbers(6, func(string) bool { return false })
+		assert.ErrorContains(t, err, "rng unavailable")
+	})
+}
+
+func TestGenerateUniqueNumbers(t *testing.T) {
+	t.Run("Retries until unused", func(t *testing.T) {
+		calls := 0
+		code, err := GenerateUniqueNumbers(6, func(string) bool {
+			calls++
+			return calls < 3 // first two codes collide
+		})
+		assert.NoError(t, err)
+		assert.Len(t, code, 6)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("Exhausted", func(t *testing.T) {
+		calls := 0
+		_, err := GenerateUniqueNumbers(6, func(string) bool {
+			calls++
+			return true
+		})
+		assert.ErrorIs(t, err, ErrUniqueExhausted)
+		assert.Equal(t, maxUniqueAttempts, calls)
+	})
+
+	t.Run("Invalid length", func(t *testing.T) {
+		_, err := GenerateUniqueNumbers(0, func(string) bool { return false })
+		assert.Error(t, err)
+	})
+
+	t.Run("Nil exists", func(t *testing.T) {
+		var code string
+		var err error
+		assert.NotPanics(t, func() { code, err = GenerateUniqueNumbers(6, nil) })
+		assert.EqualError(t, err, "exists must not be nil")
+		assert.Empty(t, code)
+	})
+}
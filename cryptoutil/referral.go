@@ -0,0 +1,75 @@
+package cryptoutil
+
+import "strings"
+
+// ReferralCode generates an uppercase alphanumeric code of `length` random
+// characters (A-Z, 0-9) followed by one check character, so the result is
+// length+1 characters long. The check character uses the Luhn mod 36
+// algorithm, which catches every single-character typo and most swaps of
+// adjacent characters before a DB lookup.
+// Returns empty string if length <= 0.
+//
+// Example:
+//
+//	code := cryptoutil.ReferralCode(7) // "K9P2M7XQ"
+func ReferralCode(length int) string {
+	if length <= 0 {
+		return ""
+	}
+	body := String(length)
+	return body + string(letters[luhnCheck(body)])
+}
+
+// ReferralCodeE is ReferralCode, returning the crypto/rand error instead of panicking.
+func ReferralCodeE(length int) (string, error) {
+	if length <= 0 {
+		return "", nil
+	}
+	body, err := StringE(length)
+	if err != nil {
+		return "", err
+	}
+	return body + string(letters[luhnCheck(body)]), nil
+}
+
+// ValidateReferralCode reports whether code carries a valid check character.
+// Case-insensitive, since codes are often shared verbally or retyped.
+//
+// Example:
+//
+//	cryptoutil.ValidateReferralCode("k9p2m7xq") // true if generated by ReferralCode
+func ValidateReferralCode(code string) bool {
+	code = strings.ToUpper(code)
+	if len(code) < 2 {
+		return false
+	}
+
+	// Luhn mod N: the weighted sum including the check character is a multiple of N
+	n := len(letters)
+	sum := 0
+	factor := 1
+	for i := len(code) - 1; i >= 0; i-- {
+		cp := strings.IndexByte(letters, code[i])
+		if cp < 0 {
+			return false
+		}
+		addend := factor * cp
+		factor = 3 - factor // alternate 1, 2
+		sum += addend/n + addend%n
+	}
+	return sum%n == 0
+}
+
+// luhnCheck returns the index in letters of the Luhn mod 36 check character for body.
+// body must only contain characters from letters.
+func luhnCheck(body string) int {
+	n := len(letters)
+	sum := 0
+	factor := 2 // the rightmost body character is doubled
+	for i := len(body) - 1; i >= 0; i-- {
+		addend := factor * strings.IndexByte(letters, body[i])
+		factor = 3 - factor
+		sum += addend/n + addend%n
+	}
+	return (n - sum%n) % n
+}
@@ -0,0 +1,39 @@
+package cryptoutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReferralCode(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		code := ReferralCode(7)
+		assert.Len(t, code, 8)
+		assert.Equal(t, strings.ToUpper(code), code)
+		assert.True(t, ValidateReferralCode(code), code)
+		assert.True(t, ValidateReferralCode(strings.ToLower(code)), code)
+	}
+
+	assert.Equal(t, "", ReferralCode(0))
+}
+
+func TestValidateReferralCode(t *testing.T) {
+	code := ReferralCode(8)
+
+	// Every single-character substitution is caught
+	for i := 0; i < len(code); i++ {
+		for _, c := range letters {
+			if byte(c) == code[i] {
+				continue
+			}
+			typo := code[:i] + string(c) + code[i+1:]
+			assert.False(t, ValidateReferralCode(typo), "typo %q of %q", typo, code)
+		}
+	}
+
+	assert.False(t, ValidateReferralCode(""))
+	assert.False(t, ValidateReferralCode("A"))
+	assert.False(t, ValidateReferralCode("AB-CD"))
+}
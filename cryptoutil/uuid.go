@@ -41,6 +41,15 @@ func V4() string {
 	return uuid.New().String()
 }
 
+// V4E is V4, returning the crypto/rand error instead of panicking.
+func V4E() (string, error) {
+	u, err := uuid.NewRandom()
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
 // V4UUID returns a random UUID v4 as uuid.UUID (zero heap allocation).
 //
 // Use this when storing the ID in a struct or passing it through hot code paths.
@@ -71,6 +80,15 @@ func V7() string {
 	return u.String()
 }
 
+// V7E is V7, returning the generation error instead of an all-zero UUID.
+func V7E() (string, error) {
+	u, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
 // V7UUID returns a time-ordered UUID v7 as uuid.UUID (zero heap allocation).
 //
 // This is the single best choice for primary keys and high-throughput systems.
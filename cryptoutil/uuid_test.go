@@ -51,6 +51,24 @@ func TestUUIDV7(t *testing.T) {
 	})
 }
 
+func TestUUIDErrorVariants(t *testing.T) {
+	v4, err := V4E()
+	assert.NoError(t, err)
+	assert.True(t, IsValid(v4))
+
+	v7, err := V7E()
+	assert.NoError(t, err)
+	assert.True(t, IsValid(v7))
+
+	uuid.SetRand(failingReader{})
+	defer uuid.SetRand(nil)
+
+	_, err = V4E()
+	assert.Error(t, err)
+	_, err = V7E()
+	assert.Error(t, err)
+}
+
 func TestUUIDValidation(t *testing.T) {
 	validUUID := "501438f4-2c63-42e8-b789-29158fbbe578"
 	invalidUUID := "not-a-uuid"
@@ -0,0 +1,96 @@
+package format
+
+import "strconv"
+
+// =============================================================================
+// ADDRESS HELPERS
+// =============================================================================
+
+// postalRange maps a range of two-digit postal code prefixes to a province.
+type postalRange struct {
+	lo, hi   int
+	province string
+}
+
+// postalRangesID is the Indonesian postal code table by first two digits.
+// Where provinces split a prefix (Sulawesi Barat within 91, Maluku Utara
+// within 97), the larger province is returned.
+var postalRangesID = []postalRange{
+	{10, 14, "DKI Jakarta"},
+	{15, 15, "Banten"},
+	{16, 17, "Jawa Barat"},
+	{20, 22, "Sumatera Utara"},
+	{23, 24, "Aceh"},
+	{25, 27, "Sumatera Barat"},
+	{28, 28, "Riau"},
+	{29, 29, "Kepulauan Riau"},
+	{30, 32, "Sumatera Selatan"},
+	{33, 33, "Kepulauan Bangka Belitung"},
+	{34, 35, "Lampung"},
+	{36, 37, "Jambi"},
+	{38, 39, "Bengkulu"},
+	{40, 41, "Jawa Barat"},
+	{42, 42, "Banten"},
+	{43, 46, "Jawa Barat"},
+	{50, 54, "Jawa Tengah"},
+	{55, 55, "DI Yogyakarta"},
+	{56, 59, "Jawa Tengah"},
+	{60, 69, "Jawa Timur"},
+	{70, 72, "Kalimantan Selatan"},
+	{73, 74, "Kalimantan Tengah"},
+	{75, 76, "Kalimantan Timur"},
+	{77, 77, "Kalimantan Utara"},
+	{78, 79, "Kalimantan Barat"},
+	{80, 82, "Bali"},
+	{83, 84, "Nusa Tenggara Barat"},
+	{85, 87, "Nusa Tenggara Timur"},
+	{90, 92, "Sulawesi Selatan"},
+	{93, 93, "Sulawesi Tenggara"},
+	{94, 94, "Sulawesi Tengah"},
+	{95, 95, "Sulawesi Utara"},
+	{96, 96, "Gorontalo"},
+	{97, 97, "Maluku"},
+	{98, 98, "Papua Barat"},
+	{99, 99, "Papua"},
+}
+
+// ValidatePostalCodeID reports whether s is an Indonesian postal code:
+// exactly 5 ASCII digits, no separators or surrounding spaces.
+//
+// Example:
+//
+//	ValidatePostalCodeID("12190") // true
+//	ValidatePostalCodeID("1219")  // false
+func ValidatePostalCodeID(s string) bool {
+	if len(s) != 5 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// ProvinceByPostalCode returns the province for an Indonesian postal code,
+// based on its first two digits. Returns "", false for invalid codes or
+// unassigned prefixes. The lookup is by prefix only, so border areas in
+// newer provinces may map to the province they were split from.
+//
+// Example:
+//
+//	ProvinceByPostalCode("40115") // "Jawa Barat", true
+//	ProvinceByPostalCode("55281") // "DI Yogyakarta", true
+func ProvinceByPostalCode(s string) (string, bool) {
+	if !ValidatePostalCodeID(s) {
+		return "", false
+	}
+	prefix, _ := strconv.Atoi(s[:2])
+	for _, r := range postalRangesID {
+		if prefix >= r.lo && prefix <= r.hi {
+			return r.province, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,45 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePostalCodeID(t *testing.T) {
+	assert.True(t, ValidatePostalCodeID("12190"))
+	assert.True(t, ValidatePostalCodeID("99351"))
+	assert.False(t, ValidatePostalCodeID("1219"))
+	assert.False(t, ValidatePostalCodeID("121900"))
+	assert.False(t, ValidatePostalCodeID("12a90"))
+	assert.False(t, ValidatePostalCodeID(" 1219"))
+	assert.False(t, ValidatePostalCodeID(""))
+}
+
+func TestProvinceByPostalCode(t *testing.T) {
+	tests := []struct {
+		code     string
+		province string
+		ok       bool
+	}{
+		{"12190", "DKI Jakarta", true},
+		{"15111", "Banten", true},
+		{"40115", "Jawa Barat", true},
+		{"42111", "Banten", true},
+		{"55281", "DI Yogyakarta", true},
+		{"60111", "Jawa Timur", true},
+		{"80361", "Bali", true},
+		{"99351", "Papua", true},
+		{"18000", "", false}, // unassigned prefix
+		{"00000", "", false},
+		{"1219", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			province, ok := ProvinceByPostalCode(tt.code)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.province, province)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package format
+
+import (
+	"strconv"
+	"time"
+)
+
+// =============================================================================
+// AGE & DEMOGRAPHIC HELPERS
+// =============================================================================
+
+// AgeUnknown is returned by AgeBracket and Generation for a zero or future dob.
+const AgeUnknown = "unknown"
+
+// DefaultAgeBrackets are the lower bounds used by AgeBracket:
+// "<18", "18-24", "25-34", "35-44", "45-54", "55-64", "65+".
+var DefaultAgeBrackets = []int{18, 25, 35, 45, 55, 65}
+
+// Age returns the completed years between dob and at, using the calendar
+// date of each. A Feb 29 birthday counts from Mar 1 in non-leap years.
+// Returns 0 if dob is zero or after at.
+//
+// Example:
+//
+//	format.Age(time.Date(1990, 5, 20, 0, 0, 0, 0, format.WIB), time.Now())
+func Age(dob, at time.Time) int {
+	if dob.IsZero() || dob.After(at) {
+		return 0
+	}
+
+	age := at.Year() - dob.Year()
+	// Birthday not reached yet this year
+	if at.Month() < dob.Month() || (at.Month() == dob.Month() && at.Day() < dob.Day()) {
+		age--
+	}
+	return age
+}
+
+// AgeBracket returns the DefaultAgeBrackets label for the age at `at`,
+// e.g. "25-34". Returns "unknown" for a zero or future dob.
+//
+// Example:
+//
+//	format.AgeBracket(dob, time.Now()) // "25-34"
+func AgeBracket(dob, at time.Time) string {
+	return AgeBracketWith(dob, at, DefaultAgeBrackets)
+}
+
+// AgeBracketWith is AgeBracket with custom ascending lower bounds.
+// Labels are "<first", "a-b" for each inner range, and "last+".
+// Returns "unknown" for a zero or future dob, or if bounds is empty.
+//
+// Example:
+//
+//	format.AgeBracketWith(dob, time.Now(), []int{17, 21, 60}) // "<17", "17-20", "21-59", "60+"
+func AgeBracketWith(dob, at time.Time, bounds []int) string {
+	if dob.IsZero() || dob.After(at) || len(bounds) == 0 {
+		return AgeUnknown
+	}
+
+	age := Age(dob, at)
+	if age < bounds[0] {
+		return "<" + strconv.Itoa(bounds[0])
+	}
+	for i := 1; i < len(bounds); i++ {
+		if age < bounds[i] {
+			return strconv.Itoa(bounds[i-1]) + "-" + strconv.Itoa(bounds[i]-1)
+		}
+	}
+	return strconv.Itoa(bounds[len(bounds)-1]) + "+"
+}
+
+// Generation returns the generation label for a birth year, using the
+// Pew Research Center ranges:
+//
+//	<= 1927     Greatest
+//	1928-1945   Silent
+//	1946-1964   Baby Boomer
+//	1965-1980   Gen X
+//	1981-1996   Millennial
+//	1997-2012   Gen Z
+//	>= 2013     Gen Alpha
+//
+// Returns "unknown" for a zero dob or one in the future.
+//
+// Example:
+//
+//	format.Generation(time.Date(1995, 1, 1, 0, 0, 0, 0, format.WIB)) // "Millennial"
+func Generation(dob time.Time) string {
+	if dob.IsZero() || dob.After(now()) {
+		return AgeUnknown
+	}
+
+	switch year := dob.Year(); {
+	case year <= 1927:
+		return "Greatest"
+	case year <= 1945:
+		return "Silent"
+	case year <= 1964:
+		return "Baby Boomer"
+	case year <= 1980:
+		return "Gen X"
+	case year <= 1996:
+		return "Millennial"
+	case year <= 2012:
+		return "Gen Z"
+	default:
+		return "Gen Alpha"
+	}
+}
@@ -0,0 +1,78 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, WIB)
+}
+
+func TestAge(t *testing.T) {
+	at := date(2025, 6, 15)
+
+	tests := []struct {
+		name     string
+		dob      time.Time
+		at       time.Time
+		expected int
+	}{
+		{"birthday passed", date(1990, 1, 1), at, 35},
+		{"birthday today", date(1990, 6, 15), at, 35},
+		{"birthday tomorrow", date(1990, 6, 16), at, 34},
+		{"leap day before mar 1", date(2000, 2, 29), date(2025, 2, 28), 24},
+		{"leap day on mar 1", date(2000, 2, 29), date(2025, 3, 1), 25},
+		{"zero dob", time.Time{}, at, 0},
+		{"future dob", date(2030, 1, 1), at, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Age(tt.dob, tt.at))
+		})
+	}
+}
+
+func TestAgeBracket(t *testing.T) {
+	at := date(2025, 6, 15)
+
+	assert.Equal(t, "<18", AgeBracket(date(2010, 1, 1), at))
+	assert.Equal(t, "18-24", AgeBracket(date(2007, 6, 15), at))
+	assert.Equal(t, "25-34", AgeBracket(date(1995, 1, 1), at))
+	assert.Equal(t, "35-44", AgeBracket(date(1990, 6, 15), at))
+	assert.Equal(t, "65+", AgeBracket(date(1950, 1, 1), at))
+	assert.Equal(t, AgeUnknown, AgeBracket(time.Time{}, at))
+	assert.Equal(t, AgeUnknown, AgeBracket(date(2030, 1, 1), at))
+
+	// Custom bounds
+	bounds := []int{17, 21, 60}
+	assert.Equal(t, "<17", AgeBracketWith(date(2010, 1, 1), at, bounds))
+	assert.Equal(t, "17-20", AgeBracketWith(date(2006, 1, 1), at, bounds))
+	assert.Equal(t, "21-59", AgeBracketWith(date(1990, 1, 1), at, bounds))
+	assert.Equal(t, "60+", AgeBracketWith(date(1960, 1, 1), at, bounds))
+	assert.Equal(t, AgeUnknown, AgeBracketWith(date(1990, 1, 1), at, nil))
+}
+
+func TestGeneration(t *testing.T) {
+	tests := []struct {
+		year     int
+		expected string
+	}{
+		{1920, "Greatest"},
+		{1945, "Silent"},
+		{1946, "Baby Boomer"},
+		{1970, "Gen X"},
+		{1996, "Millennial"},
+		{1997, "Gen Z"},
+		{2015, "Gen Alpha"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, Generation(date(tt.year, 6, 1)), "year %d", tt.year)
+	}
+	assert.Equal(t, AgeUnknown, Generation(time.Time{}))
+	assert.Equal(t, AgeUnknown, Generation(time.Now().AddDate(1, 0, 0)))
+}
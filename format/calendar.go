@@ -0,0 +1,150 @@
+package format
+
+import "time"
+
+// =============================================================================
+// CALENDAR HELPERS
+// =============================================================================
+
+// ISOWeek returns the ISO 8601 year and week number of t as seen in
+// DefaultLocation (WIB unless changed). Near midnight the local day decides the
+// week, e.g. Sunday 20:00 UTC is already Monday (next week) in WIB.
+// The ISO year may differ from the calendar year in the first/last days of January/December.
+//
+// Example:
+//
+//	year, week := format.ISOWeek(time.Date(2024, 12, 30, 0, 0, 0, 0, format.WIB)) // 2025, 1
+func ISOWeek(t time.Time) (year, week int) {
+	return t.In(defaultLocation()).ISOWeek()
+}
+
+// WeekStartEnd returns the bounds of ISO week `week` of ISO year `year` in loc:
+// start is Monday 00:00:00 and end is the last nanosecond of Sunday.
+// A nil loc uses DefaultLocation. Weeks beyond the last week of the year roll
+// over into the next year (as time.AddDate does).
+//
+// Example:
+//
+//	start, end := format.WeekStartEnd(2025, 1, format.WIB)
+//	// start: Mon 2024-12-30 00:00, end: Sun 2025-01-05 23:59:59.999999999
+func WeekStartEnd(year, week int, loc *time.Location) (start, end time.Time) {
+	if loc == nil {
+		loc = defaultLocation()
+	}
+
+	// January 4th is always in ISO week 1
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, loc)
+	// Days since Monday (Monday=0 ... Sunday=6)
+	sinceMonday := (int(jan4.Weekday()) + 6) % 7
+	week1Monday := jan4.AddDate(0, 0, -sinceMonday)
+
+	start = week1Monday.AddDate(0, 0, (week-1)*7)
+	end = start.AddDate(0, 0, 7).Add(-time.Nanosecond)
+	return start, end
+}
+
+// Quarter returns the calendar quarter (1-4) of t as seen in DefaultLocation.
+//
+// Example:
+//
+//	format.Quarter(time.Date(2025, 5, 10, 0, 0, 0, 0, format.WIB)) // 2
+func Quarter(t time.Time) int {
+	return (int(t.In(defaultLocation()).Month())-1)/3 + 1
+}
+
+// FiscalQuarter returns the fiscal year and quarter of t (in DefaultLocation) for a
+// fiscal year starting on the first day of fiscalYearStart. The fiscal year is
+// labelled by the calendar year it starts in: with an April start, Feb 2026 is
+// fiscal year 2025, quarter 4. An invalid month is treated as January.
+//
+// Example:
+//
+//	year, q := format.FiscalQuarter(time.Date(2025, 5, 10, 0, 0, 0, 0, format.WIB), time.April) // 2025, 1
+func FiscalQuarter(t time.Time, fiscalYearStart time.Month) (year, quarter int) {
+	if fiscalYearStart < time.January || fiscalYearStart > time.December {
+		fiscalYearStart = time.January
+	}
+
+	local := t.In(defaultLocation())
+	year = local.Year()
+	// Months elapsed since the fiscal year started (0-11), wrapping across years
+	offset := int(local.Month() - fiscalYearStart)
+	if offset < 0 {
+		offset += 12
+		year--
+	}
+	return year, offset/3 + 1
+}
+
+// QuarterBounds returns the bounds of calendar quarter `quarter` (1-4) of `year` in loc:
+// start is the first day 00:00:00 and end is the last nanosecond of the quarter.
+// A nil loc uses DefaultLocation. Quarters outside 1-4 roll over into adjacent years.
+//
+// Example:
+//
+//	start, end := format.QuarterBounds(2025, 2, format.WIB)
+//	// start: 2025-04-01 00:00, end: 2025-06-30 23:59:59.999999999
+func QuarterBounds(year, quarter int, loc *time.Location) (start, end time.Time) {
+	if loc == nil {
+		loc = defaultLocation()
+	}
+
+	start = time.Date(year, time.Month((quarter-1)*3+1), 1, 0, 0, 0, 0, loc)
+	end = start.AddDate(0, 3, 0).Add(-time.Nanosecond)
+	return start, end
+}
+
+// DiffCalendar returns the calendar difference between from and to in whole
+// years, months, and days, e.g. for "member for 2 years, 3 months".
+// Only calendar dates count (time of day is ignored); to is read in from's location.
+// A month counts once its day-of-month is reached, and month ends are clamped:
+// Jan 31 → Feb 28 is 0y 0m 28d, Jan 31 → Mar 1 is 0y 1m 1d (one month lands
+// on Feb 28, then one day). This matches java.time.Period.between.
+//
+// If from is after to, all three values are negative: DiffCalendar(b, a)
+// equals the negated DiffCalendar(a, b).
+//
+// Example:
+//
+//	y, m, d := format.DiffCalendar(joinedAt, time.Now()) // 2, 3, 14
+func DiffCalendar(from, to time.Time) (years, months, days int) {
+	// Work on civil dates in from's location
+	to = to.In(from.Location())
+	a := civilDate(from)
+	b := civilDate(to)
+
+	if a.After(b) {
+		y, m, d := DiffCalendar(to, from)
+		return -y, -m, -d
+	}
+
+	total := (b.Year()-a.Year())*12 + int(b.Month()-a.Month())
+	days = b.Day() - a.Day()
+	// Day-of-month not reached yet: the last month is incomplete
+	if days < 0 {
+		total--
+		anchor := addMonthsClamped(a, total)
+		days = int(b.Sub(anchor).Hours() / 24)
+	}
+	return total / 12, total % 12, days
+}
+
+// civilDate returns t's calendar date at midnight UTC, so day arithmetic
+// is free of DST and offset effects.
+func civilDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// addMonthsClamped adds n months to t, clamping the day to the target month's
+// length instead of overflowing like time.AddDate (Jan 31 + 1 month = Feb 28).
+func addMonthsClamped(t time.Time, n int) time.Time {
+	y, m, d := t.Date()
+	first := time.Date(y, m+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+	// Last day of the target month
+	lastDay := first.AddDate(0, 1, -1).Day()
+	if d > lastDay {
+		d = lastDay
+	}
+	return time.Date(first.Year(), first.Month(), d, 0, 0, 0, 0, time.UTC)
+}
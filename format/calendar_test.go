@@ -0,0 +1,133 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestISOWeek(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		year int
+		week int
+	}{
+		{"mid year", time.Date(2025, 6, 15, 12, 0, 0, 0, WIB), 2025, 24},
+		{"late december in week 1", time.Date(2024, 12, 30, 10, 0, 0, 0, WIB), 2025, 1},
+		{"early january in week 53", time.Date(2021, 1, 1, 10, 0, 0, 0, WIB), 2020, 53},
+		{"utc sunday night is monday in WIB", time.Date(2025, 1, 5, 20, 0, 0, 0, time.UTC), 2025, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			year, week := ISOWeek(tt.t)
+			assert.Equal(t, tt.year, year)
+			assert.Equal(t, tt.week, week)
+		})
+	}
+}
+
+func TestWeekStartEnd(t *testing.T) {
+	t.Run("week 1 spanning year boundary", func(t *testing.T) {
+		start, end := WeekStartEnd(2025, 1, WIB)
+		assert.Equal(t, time.Date(2024, 12, 30, 0, 0, 0, 0, WIB), start)
+		assert.Equal(t, time.Date(2025, 1, 5, 23, 59, 59, 999999999, WIB), end)
+		assert.Equal(t, time.Monday, start.Weekday())
+		assert.Equal(t, time.Sunday, end.Weekday())
+	})
+
+	t.Run("week 53", func(t *testing.T) {
+		start, end := WeekStartEnd(2020, 53, WIB)
+		assert.Equal(t, time.Date(2020, 12, 28, 0, 0, 0, 0, WIB), start)
+		assert.Equal(t, time.Date(2021, 1, 3, 23, 59, 59, 999999999, WIB), end)
+	})
+
+	t.Run("round trip with ISOWeek", func(t *testing.T) {
+		for week := 1; week <= 52; week++ {
+			start, end := WeekStartEnd(2025, week, WIB)
+			y1, w1 := ISOWeek(start)
+			y2, w2 := ISOWeek(end)
+			assert.Equal(t, [2]int{2025, week}, [2]int{y1, w1})
+			assert.Equal(t, [2]int{2025, week}, [2]int{y2, w2})
+		}
+	})
+
+	t.Run("nil location uses default", func(t *testing.T) {
+		start, _ := WeekStartEnd(2025, 10, nil)
+		assert.Equal(t, WIB, start.Location())
+	})
+}
+
+func TestQuarter(t *testing.T) {
+	assert.Equal(t, 1, Quarter(date(2025, 1, 1)))
+	assert.Equal(t, 1, Quarter(date(2025, 3, 31)))
+	assert.Equal(t, 2, Quarter(date(2025, 4, 1)))
+	assert.Equal(t, 4, Quarter(date(2025, 12, 31)))
+	// 20:00 UTC on Mar 31 is already Apr 1 in WIB
+	assert.Equal(t, 2, Quarter(time.Date(2025, 3, 31, 20, 0, 0, 0, time.UTC)))
+}
+
+func TestFiscalQuarter(t *testing.T) {
+	tests := []struct {
+		name    string
+		t       time.Time
+		start   time.Month
+		year    int
+		quarter int
+	}{
+		{"calendar fiscal year", date(2025, 5, 10), time.January, 2025, 2},
+		{"april start first quarter", date(2025, 4, 1), time.April, 2025, 1},
+		{"april start wraps year", date(2026, 2, 15), time.April, 2025, 4},
+		{"october start", date(2025, 12, 1), time.October, 2025, 1},
+		{"october start before start", date(2025, 9, 30), time.October, 2024, 4},
+		{"invalid month as january", date(2025, 8, 1), 13, 2025, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			year, quarter := FiscalQuarter(tt.t, tt.start)
+			assert.Equal(t, tt.year, year)
+			assert.Equal(t, tt.quarter, quarter)
+		})
+	}
+}
+
+func TestQuarterBounds(t *testing.T) {
+	start, end := QuarterBounds(2025, 2, WIB)
+	assert.Equal(t, time.Date(2025, 4, 1, 0, 0, 0, 0, WIB), start)
+	assert.Equal(t, time.Date(2025, 6, 30, 23, 59, 59, 999999999, WIB), end)
+
+	start, end = QuarterBounds(2024, 4, nil)
+	assert.Equal(t, time.Date(2024, 10, 1, 0, 0, 0, 0, WIB), start)
+	assert.Equal(t, time.Date(2024, 12, 31, 23, 59, 59, 999999999, WIB), end)
+}
+
+func TestDiffCalendar(t *testing.T) {
+	tests := []struct {
+		name                string
+		from, to            time.Time
+		years, months, days int
+	}{
+		{"same day", date(2025, 1, 1), date(2025, 1, 1), 0, 0, 0},
+		{"years months days", date(2022, 3, 10), date(2024, 6, 24), 2, 3, 14},
+		{"day not reached", date(2024, 1, 20), date(2024, 3, 10), 0, 1, 19},
+		{"month end clamp", date(2025, 1, 31), date(2025, 2, 28), 0, 0, 28},
+		{"past month end", date(2025, 1, 31), date(2025, 3, 1), 0, 1, 1},
+		{"leap year feb", date(2024, 1, 31), date(2024, 2, 29), 0, 0, 29},
+		{"leap day anniversary", date(2024, 2, 29), date(2025, 2, 28), 0, 11, 30},
+		{"leap day full year", date(2024, 2, 29), date(2025, 3, 1), 1, 0, 1},
+		{"across year end", date(2024, 12, 15), date(2025, 1, 14), 0, 0, 30},
+		{"reversed is negative", date(2024, 6, 24), date(2022, 3, 10), -2, -3, -14},
+		{"time of day ignored", time.Date(2025, 1, 1, 23, 0, 0, 0, WIB), time.Date(2025, 1, 2, 1, 0, 0, 0, WIB), 0, 0, 1},
+		{"to read in from location", date(2025, 1, 1), time.Date(2025, 1, 31, 20, 0, 0, 0, time.UTC), 0, 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			y, m, d := DiffCalendar(tt.from, tt.to)
+			assert.Equal(t, []int{tt.years, tt.months, tt.days}, []int{y, m, d})
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package format
+
+import (
+	"strconv"
+	"strings"
+)
+
+// =============================================================================
+// CURRENCY HELPERS
+// =============================================================================
+
+// Currency describes how amounts of one currency are displayed.
+type Currency struct {
+	Code       string // ISO 4217 code, e.g. "USD"
+	Prefix     string // written before the number, e.g. "$" or "Rp "
+	Suffix     string // written after the number, e.g. " €"
+	Decimals   int    // minor unit digits: 2 for USD, 0 for IDR/JPY
+	DecimalSep string // separator before the minor units
+	GroupSep   string // thousands separator
+}
+
+// currencies holds the supported display conventions, keyed by ISO 4217 code.
+var currencies = map[string]Currency{
+	"IDR": {Code: "IDR", Prefix: "Rp ", Decimals: 0, DecimalSep: ",", GroupSep: "."},
+	"USD": {Code: "USD", Prefix: "$", Decimals: 2, DecimalSep: ".", GroupSep: ","},
+	"SGD": {Code: "SGD", Prefix: "S$", Decimals: 2, DecimalSep: ".", GroupSep: ","},
+	"MYR": {Code: "MYR", Prefix: "RM", Decimals: 2, DecimalSep: ".", GroupSep: ","},
+	"JPY": {Code: "JPY", Prefix: "¥", Decimals: 0, DecimalSep: ".", GroupSep: ","},
+	"EUR": {Code: "EUR", Suffix: " €", Decimals: 2, DecimalSep: ",", GroupSep: "."},
+}
+
+// CurrencyByCode returns the display convention for an ISO 4217 code
+// (case-insensitive). Unknown codes return the IDR convention with the code
+// itself as prefix (e.g. "THB 1.500") and false, so callers can log them.
+//
+// Example:
+//
+//	c, ok := format.CurrencyByCode("usd") // c.Decimals == 2, ok == true
+func CurrencyByCode(code string) (Currency, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if c, ok := currencies[code]; ok {
+		return c, true
+	}
+
+	c := currencies["IDR"]
+	c.Code = code
+	c.Prefix = code + " "
+	return c, false
+}
+
+// FormatCurrency formats amount, given in the currency's minor unit (cents for
+// USD, whole rupiah for IDR), with its symbol, decimal places, and separators.
+// Integer amounts avoid float rounding errors. Unknown codes fall back to the
+// IDR convention; use CurrencyByCode to detect them.
+//
+// Example:
+//
+//	FormatCurrency(123456789, "USD") // "$1,234,567.89"
+//	FormatCurrency(1500000, "IDR")   // "Rp 1.500.000"
+//	FormatCurrency(-250, "SGD")      // "-S$2.50"
+func FormatCurrency(amount int64, code string) string {
+	c, _ := CurrencyByCode(code)
+	return c.Format(amount)
+}
+
+// Format formats amount, given in minor units, using c's conventions.
+// See FormatCurrency.
+func (c Currency) Format(amount int64) string {
+	// Negating as uint64 also handles math.MinInt64
+	abs := uint64(amount)
+	if amount < 0 {
+		abs = -abs
+	}
+	digits := strconv.FormatUint(abs, 10)
+
+	// Split off the minor units, left-padding small amounts ("5" → "0.05")
+	intPart, fracPart := digits, ""
+	if c.Decimals > 0 {
+		if len(digits) <= c.Decimals {
+			digits = strings.Repeat("0", c.Decimals-len(digits)+1) + digits
+		}
+		intPart, fracPart = digits[:len(digits)-c.Decimals], digits[len(digits)-c.Decimals:]
+	}
+
+	var b strings.Builder
+	if amount < 0 {
+		b.WriteByte('-')
+	}
+	b.WriteString(c.Prefix)
+	b.WriteString(groupThousands(intPart, c.GroupSep))
+	if fracPart != "" {
+		b.WriteString(c.DecimalSep)
+		b.WriteString(fracPart)
+	}
+	b.WriteString(c.Suffix)
+	return b.String()
+}
@@ -0,0 +1,47 @@
+package format
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatCurrency(t *testing.T) {
+	tests := []struct {
+		amount   int64
+		code     string
+		expected string
+	}{
+		{123456789, "USD", "$1,234,567.89"},
+		{5, "USD", "$0.05"},
+		{0, "USD", "$0.00"},
+		{-250, "SGD", "-S$2.50"},
+		{1500000, "IDR", "Rp 1.500.000"},
+		{999, "IDR", "Rp 999"},
+		{1234567, "JPY", "¥1,234,567"},
+		{123456, "EUR", "1.234,56 €"},
+		{123456, "MYR", "RM1,234.56"},
+		{1000, "usd", "$10.00"},
+		{1500, "THB", "THB 1.500"}, // unknown: IDR convention
+		{math.MinInt64, "IDR", "-Rp 9.223.372.036.854.775.808"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code+"/"+tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FormatCurrency(tt.amount, tt.code))
+		})
+	}
+}
+
+func TestCurrencyByCode(t *testing.T) {
+	c, ok := CurrencyByCode(" usd ")
+	assert.True(t, ok)
+	assert.Equal(t, "USD", c.Code)
+	assert.Equal(t, 2, c.Decimals)
+
+	c, ok = CurrencyByCode("thb")
+	assert.False(t, ok)
+	assert.Equal(t, "THB", c.Code)
+	assert.Equal(t, 0, c.Decimals)
+}
@@ -0,0 +1,101 @@
+package format
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidEmail is returned when an address is clearly malformed.
+var ErrInvalidEmail = errors.New("invalid email address")
+
+// =============================================================================
+// EMAIL HELPERS
+// =============================================================================
+
+// NormalizeEmail returns a canonical form of an email address for deduplication.
+//
+// Rules applied:
+//   - Surrounding whitespace is trimmed and the domain is lowercased.
+//   - For gmail.com / googlemail.com only: the local part is lowercased,
+//     "+tag" suffixes and dots are removed, and the domain becomes gmail.com.
+//   - Local parts of every other provider are left untouched.
+//
+// Returns ErrInvalidEmail for clearly malformed input (missing/multiple "@",
+// empty parts, whitespace, or a domain without a dot).
+//
+// Example:
+//
+//	NormalizeEmail("John.Doe+promo@GoogleMail.com") // "johndoe@gmail.com"
+//	NormalizeEmail("John.Doe@Example.COM")          // "John.Doe@example.com"
+func NormalizeEmail(s string) (string, error) {
+	local, domain, err := splitEmail(s)
+	if err != nil {
+		return "", err
+	}
+
+	// Provider-specific canonicalization
+	if domain == "gmail.com" || domain == "googlemail.com" {
+		local = strings.ToLower(local)
+		// Drop "+tag" suffix
+		if i := strings.IndexByte(local, '+'); i >= 0 {
+			local = local[:i]
+		}
+		// Gmail ignores dots in the local part
+		local = strings.ReplaceAll(local, ".", "")
+		if local == "" {
+			return "", ErrInvalidEmail
+		}
+		domain = "gmail.com"
+	}
+
+	return local + "@" + domain, nil
+}
+
+// IsDisposableEmail reports whether the address belongs to one of the given
+// disposable domains (keys must be lowercase). Subdomains also match, so
+// "x.mailinator.com" is caught by a "mailinator.com" entry.
+// Malformed addresses return false.
+//
+// Example:
+//
+//	blocked := map[string]bool{"mailinator.com": true}
+//	IsDisposableEmail("bot@mailinator.com", blocked) // true
+func IsDisposableEmail(s string, domains map[string]bool) bool {
+	_, domain, err := splitEmail(s)
+	if err != nil || len(domains) == 0 {
+		return false
+	}
+
+	// Walk up the domain labels: a.b.example.com → b.example.com → example.com
+	for {
+		if domains[domain] {
+			return true
+		}
+		i := strings.IndexByte(domain, '.')
+		if i < 0 {
+			return false
+		}
+		domain = domain[i+1:]
+	}
+}
+
+// splitEmail validates the basic shape of an address and returns the local part
+// unchanged and the domain lowercased.
+func splitEmail(s string) (local, domain string, err error) {
+	s = strings.TrimSpace(s)
+	// Exactly one "@" is required
+	if strings.Count(s, "@") != 1 || strings.ContainsAny(s, " \t\r\n") {
+		return "", "", ErrInvalidEmail
+	}
+
+	at := strings.IndexByte(s, '@')
+	local, domain = s[:at], strings.ToLower(s[at+1:])
+
+	// Domain must look like host.tld without empty labels
+	if local == "" || !strings.Contains(domain, ".") ||
+		strings.HasPrefix(domain, ".") || strings.HasSuffix(domain, ".") ||
+		strings.Contains(domain, "..") {
+		return "", "", ErrInvalidEmail
+	}
+	return local, domain, nil
+}
@@ -0,0 +1,45 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"John.Doe+promo@GoogleMail.com", "johndoe@gmail.com"},
+		{"  j.o.h.n@gmail.com ", "john@gmail.com"},
+		{"John.Doe+promo@Example.COM", "John.Doe+promo@example.com"},
+		{"budi@Company.co.id", "budi@company.co.id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := NormalizeEmail(tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+
+	invalid := []string{"", "plainaddress", "a@b@c.com", "@example.com", "user@", "user@localhost", "user@.com", "user@example..com", "us er@example.com", "+tag@gmail.com"}
+	for _, in := range invalid {
+		t.Run("invalid "+in, func(t *testing.T) {
+			_, err := NormalizeEmail(in)
+			assert.ErrorIs(t, err, ErrInvalidEmail)
+		})
+	}
+}
+
+func TestIsDisposableEmail(t *testing.T) {
+	domains := map[string]bool{"mailinator.com": true, "10minutemail.com": true}
+
+	assert.True(t, IsDisposableEmail("bot@Mailinator.com", domains))
+	assert.True(t, IsDisposableEmail("bot@inbox.mailinator.com", domains))
+	assert.False(t, IsDisposableEmail("budi@gmail.com", domains))
+	assert.False(t, IsDisposableEmail("notmailinator.com@x", domains))
+	assert.False(t, IsDisposableEmail("bot@mailinator.com", nil))
+}
@@ -0,0 +1,100 @@
+package format
+
+import (
+	"strings"
+
+	"github.com/Jkenyut/nvx-go-helper/cryptoutil"
+)
+
+// =============================================================================
+// FILENAME HELPERS
+// =============================================================================
+
+const (
+	maxFilenameLen  = 100 // total length cap, extension included
+	maxExtensionLen = 10  // including the dot
+)
+
+// SafeFilename turns an untrusted upload name into a safe filename:
+//   - directory parts are dropped ("../../etc/passwd" → "passwd", "C:\x\a.txt" → "a.txt")
+//   - control characters are removed
+//   - anything outside A-Z, a-z, 0-9, '-', '_' becomes '_' (runs collapsed)
+//   - the extension is kept, lowercased, and limited to 10 characters
+//   - the total length is capped at 100; an empty name becomes "file"
+//
+// Example:
+//
+//	SafeFilename("../../My Report (final).PDF") // "My_Report_final.pdf"
+func SafeFilename(name string) string {
+	stem, ext := splitFilename(name)
+	if len(stem)+len(ext) > maxFilenameLen {
+		stem = strings.TrimRight(stem[:maxFilenameLen-len(ext)], "_-")
+	}
+	return stem + ext
+}
+
+// SafeFilenameUnique is SafeFilename with a random 8-character suffix
+// (crypto/rand) before the extension, so concurrent uploads never collide.
+//
+// Example:
+//
+//	SafeFilenameUnique("invoice.PDF") // "invoice_k9p2m7x4.pdf"
+func SafeFilenameUnique(name string) string {
+	stem, ext := splitFilename(name)
+	suffix := "_" + cryptoutil.StringLower(8)
+	if len(stem)+len(suffix)+len(ext) > maxFilenameLen {
+		stem = strings.TrimRight(stem[:maxFilenameLen-len(suffix)-len(ext)], "_-")
+	}
+	return stem + suffix + ext
+}
+
+// splitFilename sanitizes name and returns its stem and extension (with dot).
+func splitFilename(name string) (stem, ext string) {
+	// Drop directories from both separators
+	name = strings.ReplaceAll(name, "\\", "/")
+	if i := strings.LastIndexByte(name, '/'); i >= 0 {
+		name = name[i+1:]
+	}
+
+	// A leading dot (".env") is part of the stem, not an extension
+	if i := strings.LastIndexByte(name, '.'); i > 0 {
+		ext = sanitizeFilenamePart(strings.ToLower(name[i+1:]))
+		name = name[:i]
+		if ext != "" {
+			ext = "." + ext
+			if len(ext) > maxExtensionLen {
+				ext = ext[:maxExtensionLen]
+			}
+		}
+	}
+
+	stem = sanitizeFilenamePart(name)
+	if stem == "" {
+		stem = "file"
+	}
+	return stem, ext
+}
+
+// sanitizeFilenamePart keeps [A-Za-z0-9_-], drops control characters, and
+// replaces everything else with a single '_', trimmed at both ends.
+func sanitizeFilenamePart(s string) string {
+	var b strings.Builder
+	lastUnderscore := false
+	for _, r := range s {
+		switch {
+		case r < 0x20 || r == 0x7f:
+			// Drop control characters entirely
+			continue
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-':
+			b.WriteRune(r)
+			lastUnderscore = false
+		default:
+			// Collapse runs of unsafe characters (and '_') into one '_'
+			if !lastUnderscore {
+				b.WriteByte('_')
+				lastUnderscore = true
+			}
+		}
+	}
+	return strings.Trim(b.String(), "_-")
+}
@@ -0,0 +1,54 @@
+package format
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSafeFilename(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"report.pdf", "report.pdf"},
+		{"../../My Report (final).PDF", "My_Report_final.pdf"},
+		{"..\\..\\windows\\system.ini", "system.ini"},
+		{"/etc/passwd", "passwd"},
+		{"in\x00voice\n.txt", "invoice.txt"},
+		{"laporan keuangan 2025.xlsx", "laporan_keuangan_2025.xlsx"},
+		{"foto-ktp__budi.JPEG", "foto-ktp_budi.jpeg"},
+		{".env", "env"},
+		{"..", "file"},
+		{"", "file"},
+		{"archive.tar.gz", "archive_tar.gz"},
+		{"résumé.doc", "r_sum.doc"},
+		{"weird.ext!!", "weird.ext"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SafeFilename(tt.input))
+		})
+	}
+}
+
+func TestSafeFilenameLength(t *testing.T) {
+	long := strings.Repeat("a", 300) + ".verylongextension"
+	got := SafeFilename(long)
+	assert.LessOrEqual(t, len(got), 100)
+	assert.True(t, strings.HasSuffix(got, ".verylonge"))
+
+	unique := SafeFilenameUnique(long)
+	assert.LessOrEqual(t, len(unique), 100)
+}
+
+func TestSafeFilenameUnique(t *testing.T) {
+	a := SafeFilenameUnique("../Invoice.PDF")
+	b := SafeFilenameUnique("../Invoice.PDF")
+
+	assert.Regexp(t, regexp.MustCompile(`^Invoice_[0-9a-z]{8}\.pdf$`), a)
+	assert.NotEqual(t, a, b)
+}
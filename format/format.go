@@ -4,19 +4,29 @@
 // No external dependencies → smaller binary, faster build, zero supply-chain risk.
 //
 // Contains:
-//   - String helpers: Title case, unique append
-//   - Number formatting: Currency
+//   - String helpers: Title case, unique append, truncation with ellipsis
+//   - Number formatting: Currency (IDR, USD, SGD, ...), safe division, percentages
 //   - Bank formatting: Account number (specific format)
+//   - Email helpers: normalization for dedup, disposable domain check
+//   - Phone helpers: country and Indonesian carrier from E.164 prefix
+//   - Age helpers: age, age bracket, and generation labels
+//   - Address helpers: Indonesian postal code validation and province lookup
+//   - Filename helpers: safe upload filenames
+//   - Input hygiene: control character stripping and detection
+//   - Query helpers: lenient boolean parsing (yes/no, on/off, 1/0)
 //   - Safe type-to-string conversion for logging, cache keys, filenames, etc.
 package format
 
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 // =============================================================================
@@ -102,6 +112,60 @@ func AddStringUnique(value string, slice *[]string) {
 	*slice = append(*slice, value)
 }
 
+// Ellipsis is appended by Truncate and TruncateWords when text is shortened.
+const Ellipsis = "…"
+
+// Truncate shortens s to at most max runes (not bytes, so emoji and
+// multi-byte characters are never split), ending with "…" when cut.
+// The ellipsis counts toward max; trailing spaces before it are trimmed.
+// s is returned unchanged when it already fits, and "" when max <= 0.
+//
+// Example:
+//
+//	Truncate("Nasi goreng spesial", 10) // "Nasi gore…"
+//	Truncate("Halo 👋", 10)              // "Halo 👋"
+func Truncate(s string, max int) string {
+	if max <= 0 {
+		return ""
+	}
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	// Leave room for the ellipsis
+	runes := []rune(s)
+	return strings.TrimRightFunc(string(runes[:max-1]), unicode.IsSpace) + Ellipsis
+}
+
+// TruncateWords is Truncate without cutting mid-word: it shortens s at the last
+// whitespace that fits, so the result is at most max runes including "…".
+// A single word longer than max is cut like Truncate.
+//
+// Example:
+//
+//	TruncateWords("Nasi goreng spesial", 16) // "Nasi goreng…"
+func TruncateWords(s string, max int) string {
+	if max <= 0 {
+		return ""
+	}
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+
+	cut := max - 1
+	// A cut right before whitespace already ends on a word boundary
+	if !unicode.IsSpace(runes[cut]) {
+		// Back up to the last whitespace; keep the hard cut if there is none
+		for i := cut - 1; i > 0; i-- {
+			if unicode.IsSpace(runes[i]) {
+				cut = i
+				break
+			}
+		}
+	}
+	return strings.TrimRightFunc(string(runes[:cut]), unicode.IsSpace) + Ellipsis
+}
+
 // =============================================================================
 // NUMBER & BANK HELPERS
 // =============================================================================
@@ -143,6 +207,61 @@ func BRINorek(norek string) string {
 		norek[:4], norek[4:6], norek[6:12], norek[12:14], norek[14:])
 }
 
+// StringifyID formats an int64 ID as a decimal string for JSON payloads.
+// JavaScript numbers lose precision above 2^53, so large IDs (Snowflake, etc.)
+// must travel as strings. Use response.SafeID for struct fields.
+//
+// Example:
+//
+//	StringifyID(1790123456789012345) // "1790123456789012345"
+func StringifyID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+// SafeDiv returns a / b, or 0 when b is zero or the result is not a finite
+// number, so NaN and ±Inf never leak into JSON (encoding/json rejects them).
+//
+// Example:
+//
+//	SafeDiv(10, 4) // 2.5
+//	SafeDiv(10, 0) // 0
+func SafeDiv(a, b float64) float64 {
+	if b == 0 {
+		return 0
+	}
+	q := a / b
+	if math.IsNaN(q) || math.IsInf(q, 0) {
+		return 0
+	}
+	return q
+}
+
+// Percentage formats part/whole as a percentage with the given decimal places
+// (negative means 0), rounded half away from zero, with a trailing "%".
+// A zero whole yields zero ("0%", or "0.00%" with 2 decimals) instead of NaN/Inf.
+//
+// Example:
+//
+//	Percentage(1, 3, 1)   // "33.3%"
+//	Percentage(2, 3, 0)   // "67%"
+//	Percentage(5, 0, 2)   // "0.00%"
+func Percentage(part, whole float64, decimals int) string {
+	if decimals < 0 {
+		decimals = 0
+	}
+	pct := SafeDiv(part, whole) * 100
+
+	// Round half away from zero; FormatFloat alone rounds half to even
+	scale := math.Pow(10, float64(decimals))
+	rounded := math.Round(pct*scale) / scale
+	if math.IsNaN(rounded) || math.IsInf(rounded, 0) {
+		// Overflow from scaling; keep the unrounded value
+		rounded = pct
+	}
+	// + 0 turns -0 into 0, avoiding "-0%"
+	return strconv.FormatFloat(rounded+0, 'f', decimals, 64) + "%"
+}
+
 // formatNumber is a generic number formatter used internally by Rupiah.
 // Formats num with given decimal places, decimal separator, and thousand separator.
 func formatNumber(num float64, decimals int, decSep, thouSep string) string {
@@ -165,23 +284,26 @@ func formatNumber(num float64, decimals int, decSep, thouSep string) string {
 		decPart += strings.Repeat("0", decimals-len(decPart))
 	}
 
-	// Add thousand separators
-	var intFormatted strings.Builder
+	// Assemble final string
+	result := groupThousands(intPart, thouSep) + decSep + decPart
+	if isNegative {
+		return "-" + result
+	}
+	return result
+}
+
+// groupThousands inserts sep every 3 digits from the right of an unsigned digit string.
+func groupThousands(intPart, sep string) string {
+	var b strings.Builder
 	l := len(intPart)
 	for i := 0; i < l; i++ {
 		// Add separator every 3 digits (except at start)
 		if i > 0 && (l-i)%3 == 0 {
-			intFormatted.WriteString(thouSep)
+			b.WriteString(sep)
 		}
-		intFormatted.WriteByte(intPart[i])
+		b.WriteByte(intPart[i])
 	}
-
-	// Assemble final string
-	result := intFormatted.String() + decSep + decPart
-	if isNegative {
-		return "-" + result
-	}
-	return result
+	return b.String()
 }
 
 // =============================================================================
@@ -274,3 +396,41 @@ func ToSafeString(v any) string {
 	}
 	return s
 }
+
+// boolWords maps the accepted boolean spellings (lowercase) to their value.
+var boolWords = map[string]bool{
+	"true": true, "t": true, "1": true, "yes": true, "y": true, "on": true,
+	"false": false, "f": false, "0": false, "no": false, "n": false, "off": false,
+}
+
+// ParseBool parses a boolean-ish value such as a query parameter or env var.
+// Accepts true/false, t/f, 1/0, yes/no, y/n, and on/off, case-insensitively and
+// ignoring surrounding whitespace. Anything else, including an empty string,
+// is an error, so "false" or "0" can never pass as true by a naive non-empty check.
+//
+// Example:
+//
+//	active, err := format.ParseBool(r.URL.Query().Get("active")) // "Yes" → true
+//	if err != nil {
+//	    return response.BadRequest(ctx, "active must be true or false")
+//	}
+func ParseBool(s string) (bool, error) {
+	v, ok := boolWords[strings.ToLower(strings.TrimSpace(s))]
+	if !ok {
+		return false, fmt.Errorf("invalid boolean %q", s)
+	}
+	return v, nil
+}
+
+// ParseBoolDefault is ParseBool returning def when s is empty or not a valid boolean.
+//
+// Example:
+//
+//	includeDeleted := format.ParseBoolDefault(r.URL.Query().Get("include_deleted"), false)
+func ParseBoolDefault(s string, def bool) bool {
+	v, err := ParseBool(s)
+	if err != nil {
+		return def
+	}
+	return v
+}
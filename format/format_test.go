@@ -1,9 +1,11 @@
 package format
 
 import (
+	"math"
 	"strconv"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -41,6 +43,50 @@ func TestAddStringUnique(t *testing.T) {
 	assert.Len(t, slice, 4)
 }
 
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		input    string
+		max      int
+		expected string
+	}{
+		{"Nasi goreng spesial", 10, "Nasi gore…"},
+		{"Nasi goreng spesial", 19, "Nasi goreng spesial"}, // fits exactly
+		{"Nasi goreng spesial", 6, "Nasi…"},                // trailing space trimmed
+		{"🍛🍛🍛🍛", 3, "🍛🍛…"},                                 // runes, not bytes
+		{"Halo 👋", 10, "Halo 👋"},
+		{"abc", 1, "…"},
+		{"abc", 0, ""},
+		{"", 5, ""},
+	}
+
+	for _, tt := range tests {
+		got := Truncate(tt.input, tt.max)
+		assert.Equal(t, tt.expected, got, "Truncate(%q, %d)", tt.input, tt.max)
+		assert.LessOrEqual(t, utf8.RuneCountInString(got), max(tt.max, 0))
+	}
+}
+
+func TestTruncateWords(t *testing.T) {
+	tests := []struct {
+		input    string
+		max      int
+		expected string
+	}{
+		{"Nasi goreng spesial", 16, "Nasi goreng…"},
+		{"Nasi goreng spesial", 13, "Nasi goreng…"}, // cut lands before a space
+		{"Nasi goreng spesial", 19, "Nasi goreng spesial"},
+		{"Supercalifragilistic", 8, "Superca…"}, // single long word
+		{"Makan 🍛 enak sekali", 10, "Makan 🍛…"},
+		{"abc def", 0, ""},
+	}
+
+	for _, tt := range tests {
+		got := TruncateWords(tt.input, tt.max)
+		assert.Equal(t, tt.expected, got, "TruncateWords(%q, %d)", tt.input, tt.max)
+		assert.LessOrEqual(t, utf8.RuneCountInString(got), max(tt.max, 0))
+	}
+}
+
 func TestFormatBRINorek(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -87,6 +133,44 @@ func TestFormatRupiah(t *testing.T) {
 	}
 }
 
+func TestStringifyID(t *testing.T) {
+	assert.Equal(t, "1790123456789012345", StringifyID(1790123456789012345))
+	assert.Equal(t, "0", StringifyID(0))
+	assert.Equal(t, "-42", StringifyID(-42))
+}
+
+func TestSafeDiv(t *testing.T) {
+	assert.Equal(t, 2.5, SafeDiv(10, 4))
+	assert.Equal(t, 0.0, SafeDiv(10, 0))
+	assert.Equal(t, 0.0, SafeDiv(0, 0))
+	assert.Equal(t, 0.0, SafeDiv(math.MaxFloat64, 0.5)) // overflow to +Inf
+	assert.Equal(t, 0.0, SafeDiv(math.NaN(), 1))
+}
+
+func TestPercentage(t *testing.T) {
+	tests := []struct {
+		part, whole float64
+		decimals    int
+		expected    string
+	}{
+		{1, 3, 1, "33.3%"},
+		{2, 3, 0, "67%"},
+		{1, 8, 1, "12.5%"},
+		{1, 8, 0, "13%"}, // 12.5 rounds up, not to even
+		{1, 4, 2, "25.00%"},
+		{5, 0, 0, "0%"},
+		{5, 0, 2, "0.00%"},
+		{-1, 3, 1, "-33.3%"},
+		{-0.0001, 100, 0, "0%"}, // no "-0%"
+		{3, 2, 0, "150%"},
+		{1, 3, -1, "33%"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, Percentage(tt.part, tt.whole, tt.decimals), "%v/%v", tt.part, tt.whole)
+	}
+}
+
 func TestToString(t *testing.T) {
 	now := time.Now()
 	zeroTime := time.Time{}
@@ -176,3 +260,29 @@ func TestToString(t *testing.T) {
 
 	}
 }
+
+func TestParseBool(t *testing.T) {
+	for _, s := range []string{"true", "TRUE", "t", "1", "yes", "Y", "on", " On "} {
+		v, err := ParseBool(s)
+		assert.NoError(t, err, s)
+		assert.True(t, v, s)
+	}
+	for _, s := range []string{"false", "False", "f", "0", "no", "N", "off", "OFF\n"} {
+		v, err := ParseBool(s)
+		assert.NoError(t, err, s)
+		assert.False(t, v, s)
+	}
+	for _, s := range []string{"", "  ", "2", "enabled", "yess", "-1"} {
+		_, err := ParseBool(s)
+		assert.Error(t, err, s)
+	}
+	_, err := ParseBool("maybe")
+	assert.EqualError(t, err, `invalid boolean "maybe"`)
+}
+
+func TestParseBoolDefault(t *testing.T) {
+	assert.True(t, ParseBoolDefault("yes", false))
+	assert.False(t, ParseBoolDefault("0", true))
+	assert.True(t, ParseBoolDefault("", true))
+	assert.False(t, ParseBoolDefault("garbage", false))
+}
@@ -0,0 +1,116 @@
+package format
+
+import "strings"
+
+// =============================================================================
+// PHONE HELPERS
+// =============================================================================
+
+// phoneCountryCodes maps E.164 country calling codes to ISO 3166-1 alpha-2 codes.
+// Matched by longest prefix, so "852" (HK) wins over a hypothetical "85".
+var phoneCountryCodes = map[string]string{
+	"62":  "ID", // Indonesia
+	"60":  "MY", // Malaysia
+	"65":  "SG", // Singapore
+	"66":  "TH", // Thailand
+	"63":  "PH", // Philippines
+	"84":  "VN", // Vietnam
+	"95":  "MM", // Myanmar
+	"855": "KH", // Cambodia
+	"856": "LA", // Laos
+	"670": "TL", // Timor-Leste
+	"673": "BN", // Brunei
+	"61":  "AU", // Australia
+	"81":  "JP", // Japan
+	"82":  "KR", // South Korea
+	"86":  "CN", // China
+	"852": "HK", // Hong Kong
+	"886": "TW", // Taiwan
+	"91":  "IN", // India
+	"966": "SA", // Saudi Arabia
+	"971": "AE", // United Arab Emirates
+	"44":  "GB", // United Kingdom
+	"31":  "NL", // Netherlands
+	"49":  "DE", // Germany
+	"33":  "FR", // France
+	"1":   "US", // NANP (shared with Canada and the Caribbean)
+}
+
+// phoneCarriersID maps Indonesian mobile prefixes (after +62) to carriers.
+var phoneCarriersID = map[string]string{
+	"811": "Telkomsel", "812": "Telkomsel", "813": "Telkomsel",
+	"821": "Telkomsel", "822": "Telkomsel", "823": "Telkomsel",
+	"851": "Telkomsel", "852": "Telkomsel", "853": "Telkomsel",
+	"814": "Indosat", "815": "Indosat", "816": "Indosat",
+	"855": "Indosat", "856": "Indosat", "857": "Indosat", "858": "Indosat",
+	"817": "XL", "818": "XL", "819": "XL",
+	"859": "XL", "877": "XL", "878": "XL",
+	"831": "Axis", "832": "Axis", "833": "Axis", "838": "Axis",
+	"895": "Tri", "896": "Tri", "897": "Tri", "898": "Tri", "899": "Tri",
+	"881": "Smartfren", "882": "Smartfren", "883": "Smartfren",
+	"884": "Smartfren", "885": "Smartfren", "886": "Smartfren",
+	"887": "Smartfren", "888": "Smartfren", "889": "Smartfren",
+}
+
+// PhoneCountry returns the ISO 3166-1 alpha-2 country for an E.164 number
+// ("+6281234567890" or "6281234567890"; spaces and hyphens are ignored).
+// Returns empty string for local-format numbers (leading 0) or unknown codes.
+//
+// Example:
+//
+//	PhoneCountry("+6281234567890") // "ID"
+//	PhoneCountry("+6591234567")    // "SG"
+func PhoneCountry(e164 string) string {
+	digits := e164Digits(e164)
+	// Country codes are 1-3 digits; try the longest first
+	for l := 3; l >= 1; l-- {
+		if len(digits) > l {
+			if country, ok := phoneCountryCodes[digits[:l]]; ok {
+				return country
+			}
+		}
+	}
+	return ""
+}
+
+// PhoneCarrierID returns the Indonesian mobile carrier (Telkomsel, Indosat, XL,
+// Axis, Tri, Smartfren) for an E.164 number, based on its prefix.
+// Returns empty string for non-Indonesian numbers or unknown prefixes.
+// Prefixes reflect original allocation; ported numbers keep their old prefix.
+//
+// Example:
+//
+//	PhoneCarrierID("+6281234567890") // "Telkomsel"
+func PhoneCarrierID(e164 string) string {
+	digits := e164Digits(e164)
+	if !strings.HasPrefix(digits, "62") || len(digits) < 5 {
+		return ""
+	}
+	return phoneCarriersID[digits[2:5]]
+}
+
+// e164Digits strips formatting from an E.164 number and returns its digits.
+// Returns empty string if the input is not in international form.
+func e164Digits(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "+")
+
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == ' ' || r == '-':
+			// Ignore common separators
+		default:
+			return ""
+		}
+	}
+
+	digits := b.String()
+	// Local numbers (0812...) are not E.164
+	if strings.HasPrefix(digits, "0") {
+		return ""
+	}
+	return digits
+}
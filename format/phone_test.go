@@ -0,0 +1,56 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhoneCountry(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"+6281234567890", "ID"},
+		{"6281234567890", "ID"},
+		{"+62 812-3456-7890", "ID"},
+		{"+6591234567", "SG"},
+		{"+85291234567", "HK"},
+		{"+14155552671", "US"},
+		{"081234567890", ""}, // local format
+		{"+999123", ""},      // unknown
+		{"", ""},
+		{"+62abc", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, PhoneCountry(tt.input))
+		})
+	}
+}
+
+func TestPhoneCarrierID(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"+6281234567890", "Telkomsel"},
+		{"+6285212345678", "Telkomsel"},
+		{"+6281612345678", "Indosat"},
+		{"+6287812345678", "XL"},
+		{"+6283812345678", "Axis"},
+		{"+6289612345678", "Tri"},
+		{"+6288112345678", "Smartfren"},
+		{"+6221123456", ""},  // Jakarta landline
+		{"+6591234567", ""},  // not Indonesia
+		{"081234567890", ""}, // local format
+		{"+62", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, PhoneCarrierID(tt.input))
+		})
+	}
+}
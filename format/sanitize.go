@@ -0,0 +1,142 @@
+package format
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// =============================================================================
+// INPUT HYGIENE
+// =============================================================================
+
+// These helpers are a hygiene layer for free-text fields (names, notes, comments),
+// NOT a WAF: they do not detect SQL injection or XSS. Always use parameterized
+// queries and context-aware output escaping as well. What they stop is control
+// characters reaching storage and logs, e.g. a forged log line via "\n", terminal
+// tampering via ANSI escapes, or strings silently truncated by C code at "\x00".
+
+// StripControlChars removes control sequences from s before storage:
+//   - ANSI escape sequences (e.g. "\x1b[31m"), removed whole, not just the ESC
+//   - null bytes and other C0/C1 control characters, except tab, newline, and carriage return
+//   - Unicode bidi controls (U+202A-U+202E, U+2066-U+2069) used to disguise text
+//   - invalid UTF-8 bytes
+//
+// Printable text, including non-ASCII letters and emoji, is kept as is.
+//
+// Example:
+//
+//	StripControlChars("Budi\x00\x1b[31m Santoso") // "Budi Santoso"
+func StripControlChars(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); {
+		if s[i] == 0x1b {
+			i += ansiSequenceLen(s[i:])
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r != utf8.RuneError || size > 1 {
+			if !isControlRune(r) && !isBidiControl(r) {
+				b.WriteString(s[i : i+size])
+			}
+		}
+		i += size
+	}
+	return b.String()
+}
+
+// HasSuspiciousInput reports whether s contains anything StripControlChars would
+// remove or that is otherwise malformed: null bytes, ANSI escapes, other control
+// characters (tab, newline, and carriage return are allowed), invalid UTF-8, or
+// unbalanced bidi controls (an embedding/override or isolate without its closing
+// PDF/PDI). Use it to reject input with a 400 instead of silently cleaning it.
+//
+// Example:
+//
+//	if format.HasSuspiciousInput(req.Note) {
+//	    return response.BadRequest(ctx, "note contains invalid characters")
+//	}
+func HasSuspiciousInput(s string) bool {
+	if !utf8.ValidString(s) {
+		return true
+	}
+
+	// Open bidi embeddings/overrides and isolates, closed by PDF and PDI
+	embeddings, isolates := 0, 0
+	for _, r := range s {
+		switch {
+		case isControlRune(r):
+			// Covers null bytes and ESC (ANSI escapes)
+			return true
+		case r == 0x202c: // PDF closes an embedding/override
+			if embeddings == 0 {
+				return true
+			}
+			embeddings--
+		case r >= 0x202a && r <= 0x202e:
+			embeddings++
+		case r == 0x2069: // PDI closes an isolate
+			if isolates == 0 {
+				return true
+			}
+			isolates--
+		case r >= 0x2066 && r <= 0x2068:
+			isolates++
+		}
+	}
+	return embeddings != 0 || isolates != 0
+}
+
+// isControlRune reports C0/C1 control characters and DEL, except tab, newline, and carriage return.
+func isControlRune(r rune) bool {
+	if r == '\t' || r == '\n' || r == '\r' {
+		return false
+	}
+	return r < 0x20 || (r >= 0x7f && r <= 0x9f)
+}
+
+// isBidiControl reports Unicode bidi embedding, override, and isolate controls.
+func isBidiControl(r rune) bool {
+	return (r >= 0x202a && r <= 0x202e) || (r >= 0x2066 && r <= 0x2069)
+}
+
+// ansiSequenceLen returns the byte length of the escape sequence starting with
+// ESC at s[0]: CSI ("ESC [" ... final byte), OSC ("ESC ]" ... BEL or "ESC \"),
+// or a two-byte escape. An unterminated sequence runs to the end of s.
+func ansiSequenceLen(s string) int {
+	if len(s) < 2 {
+		return len(s)
+	}
+
+	switch s[1] {
+	case '[':
+		// Parameter and intermediate bytes, then one final byte in 0x40-0x7e
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7e {
+				return i + 1
+			}
+			if s[i] < 0x20 || s[i] > 0x3f {
+				// Malformed: drop only what was parsed
+				return i
+			}
+		}
+		return len(s)
+	case ']':
+		for i := 2; i < len(s); i++ {
+			if s[i] == 0x07 {
+				return i + 1
+			}
+			if s[i] == 0x1b && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+		return len(s)
+	default:
+		if s[1] >= 0x40 && s[1] <= 0x5f {
+			return 2
+		}
+		// Lone ESC
+		return 1
+	}
+}
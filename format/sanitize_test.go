@@ -0,0 +1,63 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripControlChars(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"plain", "Budi Santoso", "Budi Santoso"},
+		{"null byte", "Budi\x00Santoso", "BudiSantoso"},
+		{"ANSI color", "\x1b[31mred\x1b[0m text", "red text"},
+		{"ANSI OSC title", "\x1b]0;pwned\x07hello", "hello"},
+		{"lone ESC", "a\x1bb", "ab"},
+		{"keeps tab and newlines", "line1\r\n\tline2", "line1\r\n\tline2"},
+		{"C1 and DEL", "a\u0085b\x7fc", "abc"},
+		{"bidi override", "invoice\u202efdp.exe", "invoicefdp.exe"},
+		{"invalid UTF-8", "ok\xffok", "okok"},
+		{"unicode kept", "Café 日本 🚀", "Café 日本 🚀"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, StripControlChars(tt.input))
+		})
+	}
+}
+
+func TestHasSuspiciousInput(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected bool
+	}{
+		{"plain", "Budi Santoso", false},
+		{"multi-line", "line1\r\n\tline2", false},
+		{"unicode", "Café 日本 🚀", false},
+		{"null byte", "a\x00b", true},
+		{"ANSI escape", "\x1b[31mred", true},
+		{"bell", "ding\x07", true},
+		{"invalid UTF-8", "ok\xff", true},
+		{"balanced embedding", "\u202bשלום\u202c", false},
+		{"balanced isolate", "\u2067שלום\u2069", false},
+		{"unclosed override", "invoice\u202efdp.exe", true},
+		{"stray PDF", "text\u202c", true},
+		{"stray PDI", "text\u2069", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, HasSuspiciousInput(tt.input))
+		})
+	}
+
+	// Stripped input is never suspicious
+	assert.False(t, HasSuspiciousInput(StripControlChars("a\x00\x1b[1mb\u202ec\xff")))
+}
@@ -0,0 +1,87 @@
+package format
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// SEQUENCE CODES
+// =============================================================================
+
+// SequenceFormat describes how document numbers such as invoices are laid out.
+// The zero value gives "PREFIX/YYYY/MM/SEQ" with the date in WIB.
+type SequenceFormat struct {
+	Separator string         // between segments (default "/")
+	Daily     bool           // add a DD segment after the month, for sequences that reset daily
+	Location  *time.Location // zone the date segments are read in (default WIB, not DefaultLocation)
+}
+
+// SequenceCode formats an invoice-style number "PREFIX/YYYY/MM/SEQ" with the
+// date of t in WIB (always, regardless of SetDefaultLocation, so numbering
+// rolls over at the same midnight in every service) and seq zero-padded to pad
+// digits. Use SequenceFormat for another separator, zone, or a day segment.
+//
+// Example:
+//
+//	format.SequenceCode("INV", time.Now(), 123, 6) // "INV/2025/01/000123"
+func SequenceCode(prefix string, t time.Time, seq int64, pad int) string {
+	return SequenceFormat{}.Code(prefix, t, seq, pad)
+}
+
+// Code formats a sequence number using f. Rules:
+//   - An empty prefix is omitted along with its separator.
+//   - Month and day are always two digits, the year four.
+//   - seq is left-padded with zeros to pad digits; longer sequences are never
+//     cut, and pad <= 0 disables padding. A negative seq keeps its sign in front.
+//
+// Example:
+//
+//	f := format.SequenceFormat{Separator: "-", Daily: true}
+//	f.Code("PO", time.Now(), 42, 4) // "PO-2025-01-15-0042"
+func (f SequenceFormat) Code(prefix string, t time.Time, seq int64, pad int) string {
+	sep := f.Separator
+	if sep == "" {
+		sep = "/"
+	}
+
+	loc := f.Location
+	if loc == nil {
+		loc = WIB
+	}
+
+	// Build the date by hand: a separator such as "1" would corrupt a time.Format layout
+	year, month, day := t.In(loc).Date()
+	segments := []string{fmt.Sprintf("%04d", year), fmt.Sprintf("%02d", int(month))}
+	if f.Daily {
+		segments = append(segments, fmt.Sprintf("%02d", day))
+	}
+	if prefix != "" {
+		segments = append([]string{prefix}, segments...)
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.Join(segments, sep))
+	b.WriteString(sep)
+
+	// Pad the digits only, so the sign stays in front
+	if seq < 0 {
+		b.WriteByte('-')
+	}
+	digits := strconv.FormatUint(absInt64(seq), 10)
+	if n := pad - len(digits); n > 0 {
+		b.WriteString(strings.Repeat("0", n))
+	}
+	b.WriteString(digits)
+	return b.String()
+}
+
+// absInt64 returns |n| as uint64, which also holds the magnitude of math.MinInt64.
+func absInt64(n int64) uint64 {
+	if n < 0 {
+		return uint64(-(n + 1)) + 1
+	}
+	return uint64(n)
+}
@@ -0,0 +1,42 @@
+package format
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSequenceCode(t *testing.T) {
+	jan := time.Date(2025, 1, 5, 10, 0, 0, 0, WIB)
+
+	assert.Equal(t, "INV/2025/01/000123", SequenceCode("INV", jan, 123, 6))
+	assert.Equal(t, "INV/2025/01/1234567", SequenceCode("INV", jan, 1234567, 6)) // never cut
+	assert.Equal(t, "INV/2025/01/7", SequenceCode("INV", jan, 7, 0))
+	assert.Equal(t, "2025/01/0042", SequenceCode("", jan, 42, 4))
+	assert.Equal(t, "INV/2025/01/-0042", SequenceCode("INV", jan, -42, 4))
+
+	// Date is read in WIB: 20:00 UTC on Jan 31 is already Feb 1
+	utc := time.Date(2025, 1, 31, 20, 0, 0, 0, time.UTC)
+	assert.Equal(t, "INV/2025/02/001", SequenceCode("INV", utc, 1, 3))
+}
+
+func TestSequenceFormat(t *testing.T) {
+	jan := time.Date(2025, 1, 5, 10, 0, 0, 0, WIB)
+
+	f := SequenceFormat{Separator: "-", Daily: true}
+	assert.Equal(t, "PO-2025-01-05-0042", f.Code("PO", jan, 42, 4))
+	assert.Equal(t, "INV.2025.01.99", SequenceFormat{Separator: "."}.Code("INV", jan, 99, 2))
+	assert.Equal(t, "INV/2025/01/05/1", SequenceFormat{Daily: true}.Code("INV", jan, 1, 1))
+	assert.Equal(t, "A12025101105100", SequenceFormat{Separator: "1", Daily: true}.Code("A", jan, 0, 2)) // no layout confusion
+
+	assert.Equal(t, "X/2025/01/-9223372036854775808", SequenceCode("X", jan, math.MinInt64, 0))
+
+	// Always WIB unless Location is set, even with another DefaultLocation
+	SetDefaultLocation(WITA)
+	defer SetDefaultLocation(WIB)
+	lateUTC := time.Date(2025, 1, 31, 16, 30, 0, 0, time.UTC) // 23:30 WIB, 00:30 WITA
+	assert.Equal(t, "INV/2025/01/1", SequenceCode("INV", lateUTC, 1, 0))
+	assert.Equal(t, "INV/2025/02/1", SequenceFormat{Location: WITA}.Code("INV", lateUTC, 1, 0))
+}
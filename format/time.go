@@ -7,7 +7,10 @@
 package format
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -22,8 +25,86 @@ var (
 	WIB     = time.FixedZone("Asia/Jakarta", 7*60*60)
 	Jakarta = WIB                                     // most commonly used alias
 	Bangkok = time.FixedZone("Asia/Bangkok", 7*60*60) // same offset as WIB
+
+	// WITA (UTC+8) and WIT (UTC+9) — central and eastern Indonesia
+	WITA = time.FixedZone("Asia/Makassar", 8*60*60)
+	WIT  = time.FixedZone("Asia/Jayapura", 9*60*60)
 )
 
+// =============================================================================
+// DEFAULT DISPLAY LOCATION
+// =============================================================================
+
+// DefaultLocation is the zone used by FormatLocal and NowLocal (default: WIB).
+// Change it only through SetDefaultLocation, which is safe for concurrent use.
+var DefaultLocation = WIB
+
+// locMu guards DefaultLocation
+var locMu sync.RWMutex
+
+// SetDefaultLocation changes the zone used by FormatLocal and NowLocal.
+// Passing nil restores WIB. Safe to call concurrently with the readers.
+//
+// Example:
+//
+//	format.SetDefaultLocation(format.WITA) // service in Makassar
+func SetDefaultLocation(loc *time.Location) {
+	if loc == nil {
+		loc = WIB
+	}
+	locMu.Lock()
+	DefaultLocation = loc
+	locMu.Unlock()
+}
+
+// defaultLocation returns DefaultLocation under the read lock.
+func defaultLocation() *time.Location {
+	locMu.RLock()
+	defer locMu.RUnlock()
+	return DefaultLocation
+}
+
+// =============================================================================
+// CLOCK
+// =============================================================================
+
+// Clock is the time source for the Now helpers (default: time.Now).
+// Change it only through SetClock/ResetClock, which are safe for concurrent use.
+var Clock func() time.Time = time.Now
+
+// clockMu guards Clock
+var clockMu sync.RWMutex
+
+// SetClock replaces the time source used by NowUTC, NowWIB, Now, NowLocal,
+// and other helpers that read the current time. Intended for tests;
+// pair it with ResetClock. Passing nil restores time.Now.
+//
+// Example:
+//
+//	format.SetClock(func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) })
+//	defer format.ResetClock()
+func SetClock(clock func() time.Time) {
+	if clock == nil {
+		clock = time.Now
+	}
+	clockMu.Lock()
+	Clock = clock
+	clockMu.Unlock()
+}
+
+// ResetClock restores time.Now as the time source.
+func ResetClock() {
+	SetClock(nil)
+}
+
+// now returns the current time from Clock under the read lock.
+func now() time.Time {
+	clockMu.RLock()
+	clock := Clock
+	clockMu.RUnlock()
+	return clock()
+}
+
 // =============================================================================
 // COMMON DATE/TIME LAYOUTS
 // =============================================================================
@@ -34,6 +115,8 @@ const (
 	LayoutISO         = "2006-01-02T15:04:05Z07:00" // ISO with offset
 	LayoutRFC3339WIB  = "2006-01-02T15:04:05+07:00" // RFC3339 with +07:00
 	LayoutDB          = "2006-01-02 15:04:05"       // MySQL / PostgreSQL default format
+	LayoutDateID      = "02/01/2006"                // 31/12/2025 (CSV exchange, day first)
+	LayoutDateTimeID  = LayoutDateTime              // alias: same layout, named for the *ID helpers
 )
 
 // =============================================================================
@@ -43,13 +126,13 @@ const (
 // NowUTC returns the current time in UTC.
 // Use this for: database storage, logging, API contracts, caching keys.
 func NowUTC() time.Time {
-	return time.Now().UTC()
+	return now().UTC()
 }
 
 // NowWIB returns the current time in WIB (UTC+7).
 // Use this for displaying time to users in that timezone.
 func NowWIB() time.Time {
-	return time.Now().In(WIB)
+	return now().In(WIB)
 }
 
 // Now returns current time in UTC (default for all internal systems).
@@ -57,6 +140,12 @@ func Now() time.Time {
 	return NowUTC()
 }
 
+// NowLocal returns the current time in DefaultLocation.
+// Use this for display in services that are not fixed to WIB.
+func NowLocal() time.Time {
+	return now().In(defaultLocation())
+}
+
 // ToWIB converts any time.Time to WIB (UTC+7).
 func ToWIB(t time.Time) time.Time {
 	return t.In(WIB)
@@ -67,6 +156,33 @@ func ToUTC(t time.Time) time.Time {
 	return t.UTC()
 }
 
+// AssumeWIB reinterprets the wall clock of t as WIB, keeping the same
+// year/month/day/hour/minute/second/nanosecond and attaching the WIB location.
+// The instant CHANGES (unless t was already WIB).
+//
+// Use this for "timestamp without time zone" columns that store WIB wall-clock
+// values but are decoded by the driver as UTC.
+//
+// AssumeWIB vs ToWIB:
+//
+//	t := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+//	ToWIB(t)     // 2025-01-02 17:00 +07:00 (same instant, different display)
+//	AssumeWIB(t) // 2025-01-02 10:00 +07:00 (same wall clock, instant moved back 7h)
+func AssumeWIB(t time.Time) time.Time {
+	return assumeIn(t, WIB)
+}
+
+// AssumeUTC reinterprets the wall clock of t as UTC (same numbers, UTC location).
+// Like AssumeWIB, the instant changes; use ToUTC to convert an instant instead.
+func AssumeUTC(t time.Time) time.Time {
+	return assumeIn(t, time.UTC)
+}
+
+// assumeIn rebuilds t's wall-clock fields in loc.
+func assumeIn(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
 // FormatWIB formats a time in WIB zone using the given layout.
 func FormatWIB(t time.Time, layout string) string {
 	return t.In(WIB).Format(layout)
@@ -77,6 +193,260 @@ func FormatUTC(t time.Time, layout string) string {
 	return t.UTC().Format(layout)
 }
 
+// FormatLocal formats a time in DefaultLocation using the given layout.
+func FormatLocal(t time.Time, layout string) string {
+	return t.In(defaultLocation()).Format(layout)
+}
+
+// =============================================================================
+// DAY-FIRST (INDONESIAN) DATE PARSING
+// =============================================================================
+
+// StringToDateID parses a DD/MM/YYYY date (LayoutDateID) at midnight in loc.
+// A nil loc uses DefaultLocation. Surrounding whitespace is ignored.
+//
+// Example:
+//
+//	t, err := StringToDateID("31/12/2025", format.WIB) // 2025-12-31 00:00 +07:00
+func StringToDateID(s string, loc *time.Location) (time.Time, error) {
+	return parseDayFirst(s, LayoutDateID, "DD/MM/YYYY", loc)
+}
+
+// StringToDateTimeID parses a DD-MM-YYYY HH:MM date-time (LayoutDateTimeID) in loc.
+// A nil loc uses DefaultLocation. Surrounding whitespace is ignored.
+//
+// Example:
+//
+//	t, err := StringToDateTimeID("31-12-2025 14:30", format.WIB)
+func StringToDateTimeID(s string, loc *time.Location) (time.Time, error) {
+	return parseDayFirst(s, LayoutDateTimeID, "DD-MM-YYYY HH:MM", loc)
+}
+
+// ToDateIDString formats t as DD/MM/YYYY in loc (nil = DefaultLocation).
+// Returns empty string for zero time.
+func ToDateIDString(t time.Time, loc *time.Location) string {
+	return formatDayFirst(t, LayoutDateID, loc)
+}
+
+// ToDateTimeIDString formats t as DD-MM-YYYY HH:MM in loc (nil = DefaultLocation).
+// Returns empty string for zero time.
+func ToDateTimeIDString(t time.Time, loc *time.Location) string {
+	return formatDayFirst(t, LayoutDateTimeID, loc)
+}
+
+// parseDayFirst parses s with layout in loc and reports the expected pattern on failure.
+func parseDayFirst(s, layout, pattern string, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = defaultLocation()
+	}
+	t, err := time.ParseInLocation(layout, strings.TrimSpace(s), loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected %s: %w", s, pattern, err)
+	}
+	return t, nil
+}
+
+// formatDayFirst formats t with layout in loc, returning "" for zero time.
+func formatDayFirst(t time.Time, layout string, loc *time.Location) string {
+	if t.IsZero() {
+		return ""
+	}
+	if loc == nil {
+		loc = defaultLocation()
+	}
+	return t.In(loc).Format(layout)
+}
+
+// =============================================================================
+// TIME OF DAY
+// =============================================================================
+
+// ParseClock parses a wall-clock time "HH:MM" or "HH:MM:SS" (24-hour) into the
+// duration since midnight, e.g. for opening hours stored in config.
+// Hours must be 0-23 (one or two digits), minutes and seconds 00-59.
+// Surrounding whitespace is ignored.
+//
+// Example:
+//
+//	open, _ := format.ParseClock("09:00")  // 9h0m0s
+//	close, _ := format.ParseClock("17:30") // 17h30m0s
+func ParseClock(s string) (time.Duration, error) {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid clock %q, expected HH:MM or HH:MM:SS", s)
+	}
+
+	// Hour may be "9" or "09"; minutes and seconds are always two digits
+	limits := []struct {
+		name      string
+		max       int
+		minDigits int
+	}{{"hour", 23, 1}, {"minute", 59, 2}, {"second", 59, 2}}
+
+	var values [3]int
+	for i, part := range parts {
+		// Digits only: Atoi alone would accept signs like "+9"
+		n, err := strconv.Atoi(part)
+		if err != nil || strings.Trim(part, "0123456789") != "" ||
+			len(part) < limits[i].minDigits || len(part) > 2 || n > limits[i].max {
+			return 0, fmt.Errorf("invalid clock %q: %s must be 0-%d", s, limits[i].name, limits[i].max)
+		}
+		values[i] = n
+	}
+	return time.Duration(values[0])*time.Hour +
+		time.Duration(values[1])*time.Minute +
+		time.Duration(values[2])*time.Second, nil
+}
+
+// ApplyClock returns the calendar date of date (as seen in loc) at time-of-day
+// clock in loc. A nil loc uses DefaultLocation. The wall clock is set directly,
+// so the result is correct across DST changes in loc. Clocks of 24h or more
+// roll over into the following days.
+//
+// Example:
+//
+//	open, _ := format.ParseClock("09:00")
+//	opensAt := format.ApplyClock(time.Now(), open, format.WIB) // today 09:00 WIB
+func ApplyClock(date time.Time, clock time.Duration, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = defaultLocation()
+	}
+	y, m, d := date.In(loc).Date()
+
+	hours := clock / time.Hour
+	clock -= hours * time.Hour
+	minutes := clock / time.Minute
+	clock -= minutes * time.Minute
+	seconds := clock / time.Second
+	clock -= seconds * time.Second
+	return time.Date(y, m, d, int(hours), int(minutes), int(seconds), int(clock), loc)
+}
+
+// WithinBusinessHours reports whether t, read as a wall clock in loc, falls in
+// [open, close). A nil loc uses DefaultLocation. When close < open the range is
+// overnight (e.g. 22:00–02:00) and wraps past midnight; open == close is never
+// open, so use 0 and 24*time.Hour for a store open all day.
+//
+// Example:
+//
+//	open, _ := format.ParseClock("22:00")
+//	close, _ := format.ParseClock("02:00")
+//	format.WithinBusinessHours(time.Now(), open, close, format.WIB) // true at 23:30 or 01:00 WIB
+func WithinBusinessHours(t time.Time, open, close time.Duration, loc *time.Location) bool {
+	if loc == nil {
+		loc = defaultLocation()
+	}
+	clock := clockOf(t.In(loc))
+	if open <= close {
+		return clock >= open && clock < close
+	}
+	// Overnight: from open until midnight, or from midnight until close
+	return clock >= open || clock < close
+}
+
+// WithinSchedule reports whether t, read in loc, falls within the opening hours
+// schedule[weekday] = {open, close}. A nil loc uses DefaultLocation and days missing
+// from schedule are closed. An overnight range (close < open) belongs to the day it
+// opens: with Friday {22:00, 02:00}, Saturday 01:00 is open even if Saturday
+// itself is missing from schedule.
+//
+// Example:
+//
+//	schedule := map[time.Weekday][2]time.Duration{
+//	    time.Monday: {9 * time.Hour, 17 * time.Hour},
+//	    time.Friday: {22 * time.Hour, 2 * time.Hour},
+//	}
+//	if !format.WithinSchedule(time.Now(), schedule, format.WIB) {
+//	    return response.BadRequest(ctx, "store is closed")
+//	}
+func WithinSchedule(t time.Time, schedule map[time.Weekday][2]time.Duration, loc *time.Location) bool {
+	if loc == nil {
+		loc = defaultLocation()
+	}
+	local := t.In(loc)
+	clock := clockOf(local)
+
+	// Today's hours, up to midnight for an overnight range
+	if hours, ok := schedule[local.Weekday()]; ok {
+		open, close := hours[0], hours[1]
+		if open <= close && clock >= open && clock < close {
+			return true
+		}
+		if open > close && clock >= open {
+			return true
+		}
+	}
+
+	// The tail of yesterday's overnight range
+	if hours, ok := schedule[(local.Weekday()+6)%7]; ok {
+		open, close := hours[0], hours[1]
+		if open > close && clock < close {
+			return true
+		}
+	}
+	return false
+}
+
+// clockOf returns the wall-clock time of day of t as a duration since midnight.
+func clockOf(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour +
+		time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second +
+		time.Duration(t.Nanosecond())
+}
+
+// =============================================================================
+// ZONE-NAMED PARSING
+// =============================================================================
+
+// fixedZones backs ParseWithZoneName when the tz database is missing
+// (e.g. scratch/distroless images without tzdata) and resolves the
+// Indonesian abbreviations, which are not IANA names.
+var fixedZones = map[string]*time.Location{
+	"Asia/Jakarta":   WIB,
+	"Asia/Pontianak": WIB,
+	"Asia/Makassar":  WITA,
+	"Asia/Jayapura":  WIT,
+	"WIB":            WIB,
+	"WITA":           WITA,
+	"WIT":            WIT,
+	"UTC":            time.UTC,
+}
+
+// ParseWithZoneName parses "YYYY-MM-DD HH:MM:SS <zone>" (LayoutDB followed by a
+// zone name), e.g. "2025-01-02 15:04:05 Asia/Jakarta" or "2025-01-02 15:04:05 WITA".
+// The zone is loaded from the tz database, falling back to the package's fixed
+// zones for Indonesian names and UTC when tzdata is unavailable.
+// Returns an error naming the zone if it is unknown.
+//
+// Example:
+//
+//	t, err := format.ParseWithZoneName("2025-01-02 15:04:05 Asia/Jakarta") // 15:04:05 +07:00
+func ParseWithZoneName(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	// Zone name is the last space-separated field
+	idx := strings.LastIndexByte(s, ' ')
+	if idx < 0 {
+		return time.Time{}, fmt.Errorf("invalid time %q, expected YYYY-MM-DD HH:MM:SS <zone>", s)
+	}
+	datetime, zone := strings.TrimSpace(s[:idx]), s[idx+1:]
+
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		fixed, ok := fixedZones[zone]
+		if !ok {
+			return time.Time{}, fmt.Errorf("unknown time zone %q in %q: %w", zone, s, err)
+		}
+		loc = fixed
+	}
+
+	t, err := time.ParseInLocation(LayoutDB, datetime, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q, expected YYYY-MM-DD HH:MM:SS <zone>: %w", s, err)
+	}
+	return t, nil
+}
+
 // ParseRFC3339Safe safely parses an RFC3339 string.
 // Returns zero time + nil error if input is empty or represents a zero/default date.
 func ParseRFC3339Safe(s string) (time.Time, error) {
@@ -94,3 +464,189 @@ func ParseRFC3339Safe(s string) (time.Time, error) {
 func IsZeroOrDefault(t time.Time) bool {
 	return t.IsZero() || t.Format("2006-01-02") == "0001-01-01"
 }
+
+// =============================================================================
+// ROUNDING
+// =============================================================================
+
+// TruncateTo rounds t down to a multiple of d on loc's wall clock
+// (nil loc = DefaultLocation), e.g. 15-minute buckets aligned to local midnight.
+// time.Truncate aligns to UTC instead, which breaks buckets such as 1h or 24h in
+// zones with a non-whole-hour offset and in zones with DST.
+// Returns t in loc unchanged if d <= 0.
+//
+// Example:
+//
+//	format.TruncateTo(t, 15*time.Minute, format.WIB) // 10:44 WIB → 10:30 WIB
+func TruncateTo(t time.Time, d time.Duration, loc *time.Location) time.Time {
+	return roundWall(t, d, loc, time.Time.Truncate)
+}
+
+// RoundTo rounds t to the nearest multiple of d on loc's wall clock
+// (nil loc = DefaultLocation); halfway values round up.
+// Returns t in loc unchanged if d <= 0.
+//
+// Example:
+//
+//	format.RoundTo(t, 15*time.Minute, format.WIB) // 10:44 WIB → 10:45 WIB
+func RoundTo(t time.Time, d time.Duration, loc *time.Location) time.Time {
+	return roundWall(t, d, loc, time.Time.Round)
+}
+
+// roundWall applies op to t's wall clock in loc (treated as UTC so the zone
+// offset does not shift the buckets) and converts the result back to loc.
+func roundWall(t time.Time, d time.Duration, loc *time.Location, op func(time.Time, time.Duration) time.Time) time.Time {
+	if loc == nil {
+		loc = defaultLocation()
+	}
+	local := t.In(loc)
+	if d <= 0 {
+		return local
+	}
+
+	wall := time.Date(local.Year(), local.Month(), local.Day(),
+		local.Hour(), local.Minute(), local.Second(), local.Nanosecond(), time.UTC)
+	wall = op(wall, d)
+
+	return time.Date(wall.Year(), wall.Month(), wall.Day(),
+		wall.Hour(), wall.Minute(), wall.Second(), wall.Nanosecond(), loc)
+}
+
+// Normalize strips the monotonic clock reading from t and truncates it to whole
+// seconds, keeping its location. Use it before comparing or caching times that
+// round-trip through JSON or a database with second precision.
+//
+// Example:
+//
+//	createdAt := format.Normalize(time.Now()) // equal to the value read back from MySQL
+func Normalize(t time.Time) time.Time {
+	// Truncate is a wall-time computation, so it also drops the monotonic reading
+	return t.Truncate(time.Second)
+}
+
+// EqualSecond reports whether a and b are the same instant at second granularity,
+// ignoring location, monotonic readings, and sub-second precision.
+//
+// Example:
+//
+//	format.EqualSecond(saved.CreatedAt, loaded.CreatedAt) // true despite lost nanoseconds
+func EqualSecond(a, b time.Time) bool {
+	return Normalize(a.UTC()).Equal(Normalize(b.UTC()))
+}
+
+// =============================================================================
+// EPOCH CONVERSION
+// =============================================================================
+
+// FromUnix converts epoch seconds to a UTC time.
+//
+// Example:
+//
+//	FromUnix(1735689600) // 2025-01-01 00:00:00 UTC
+func FromUnix(sec int64) time.Time {
+	return time.Unix(sec, 0).UTC()
+}
+
+// FromUnixMillis converts epoch milliseconds (JavaScript Date.now, Kafka) to a UTC time.
+func FromUnixMillis(ms int64) time.Time {
+	return time.UnixMilli(ms).UTC()
+}
+
+// FromUnixMicros converts epoch microseconds (PostgreSQL, BigQuery) to a UTC time.
+func FromUnixMicros(us int64) time.Time {
+	return time.UnixMicro(us).UTC()
+}
+
+// FromUnixNanos converts epoch nanoseconds to a UTC time.
+func FromUnixNanos(ns int64) time.Time {
+	return time.Unix(0, ns).UTC()
+}
+
+// ToUnix returns t as epoch seconds. A zero t returns 0 rather than the
+// large negative value of year 1, mirroring how zero times format as "".
+func ToUnix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+// ToUnixMillis returns t as epoch milliseconds (0 for zero t).
+//
+// Example:
+//
+//	ToUnixMillis(FromUnixMillis(1735689600123)) // 1735689600123
+func ToUnixMillis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+// ToUnixMicros returns t as epoch microseconds (0 for zero t).
+func ToUnixMicros(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMicro()
+}
+
+// ToUnixNanos returns t as epoch nanoseconds (0 for zero t).
+// Only times between the years 1678 and 2262 fit in an int64.
+func ToUnixNanos(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+// =============================================================================
+// EXPIRY
+// =============================================================================
+
+// NoExpiry is returned by TimeUntil for a zero expiry time (never expires).
+// SecondsUntil returns -1 in that case, matching Redis TTL semantics.
+const NoExpiry time.Duration = -1
+
+// TimeUntil returns the time left until expiry t, clamped at 0 once t has passed.
+// A zero t means "no expiry" and returns NoExpiry. Uses Clock.
+//
+// Example:
+//
+//	ttl := format.TimeUntil(session.ExpiresAt) // 14m32s
+func TimeUntil(t time.Time) time.Duration {
+	if t.IsZero() {
+		return NoExpiry
+	}
+	if d := t.Sub(now()); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// SecondsUntil returns the whole seconds left until expiry t (rounded down),
+// clamped at 0 once t has passed, e.g. for Cache-Control max-age or expires_in.
+// A zero t means "no expiry" and returns -1. Uses Clock.
+//
+// Example:
+//
+//	w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d", format.SecondsUntil(expiresAt)))
+func SecondsUntil(t time.Time) int64 {
+	d := TimeUntil(t)
+	if d == NoExpiry {
+		return -1
+	}
+	return int64(d / time.Second)
+}
+
+// IsExpired reports whether expiry t has been reached (t <= now).
+// A zero t means "no expiry" and is never expired. Uses Clock.
+//
+// Example:
+//
+//	if format.IsExpired(token.ExpiresAt) {
+//	    return response.Unauthorized(ctx, "token expired")
+//	}
+func IsExpired(t time.Time) bool {
+	return !t.IsZero() && !now().Before(t)
+}
@@ -12,6 +12,8 @@ func TestTimezoneConstants(t *testing.T) {
 	assert.Equal(t, "Asia/Jakarta", WIB.String())
 	assert.Equal(t, "Asia/Jakarta", Jakarta.String())
 	assert.Equal(t, "Asia/Bangkok", Bangkok.String())
+	assert.Equal(t, "Asia/Makassar", WITA.String())
+	assert.Equal(t, "Asia/Jayapura", WIT.String())
 }
 
 func TestNowUTC(t *testing.T) {
@@ -38,6 +40,41 @@ func TestNow(t *testing.T) {
 	assert.WithinDuration(t, t1, t2, time.Second)
 }
 
+func TestSetClock(t *testing.T) {
+	defer ResetClock()
+
+	fixed := time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC)
+	SetClock(func() time.Time { return fixed })
+
+	assert.Equal(t, fixed, NowUTC())
+	assert.Equal(t, fixed, Now())
+	assert.Equal(t, "2025-01-02 03:00:00", NowWIB().Format(LayoutDB))
+	assert.Equal(t, "2025-01-02 03:00:00", NowLocal().Format(LayoutDB))
+
+	// Generation treats dob after the clock as future
+	assert.Equal(t, AgeUnknown, Generation(time.Date(2025, 6, 1, 0, 0, 0, 0, WIB)))
+
+	ResetClock()
+	assert.WithinDuration(t, time.Now(), NowUTC(), time.Second)
+}
+
+func TestSetClock_Concurrent(t *testing.T) {
+	defer ResetClock()
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 1000; i++ {
+			SetClock(func() time.Time { return time.Unix(0, 0) })
+			ResetClock()
+		}
+		close(done)
+	}()
+	for i := 0; i < 1000; i++ {
+		_ = NowUTC()
+	}
+	<-done
+}
+
 func TestToWIB(t *testing.T) {
 	// Gunakan waktu tetap di UTC
 	utcTime := time.Date(2025, 10, 20, 8, 30, 45, 123456789, time.UTC)
@@ -129,3 +166,354 @@ func TestIsZeroOrDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultLocation(t *testing.T) {
+	defer SetDefaultLocation(nil)
+
+	// Default is WIB
+	assert.Equal(t, WIB, defaultLocation())
+	_, offset := NowLocal().Zone()
+	assert.Equal(t, 7*3600, offset)
+
+	utcTime := time.Date(2025, 10, 20, 8, 30, 0, 0, time.UTC)
+	assert.Equal(t, "20-10-2025 15:30", FormatLocal(utcTime, LayoutDateTime))
+
+	// Switch to WITA
+	SetDefaultLocation(WITA)
+	assert.Equal(t, "20-10-2025 16:30", FormatLocal(utcTime, LayoutDateTime))
+	assert.Equal(t, "Asia/Makassar", NowLocal().Location().String())
+
+	// Explicit WIB helper is unaffected
+	assert.Equal(t, "20-10-2025 15:30", FormatWIB(utcTime, LayoutDateTime))
+
+	// nil restores WIB
+	SetDefaultLocation(nil)
+	assert.Equal(t, WIB, defaultLocation())
+}
+
+func TestSetDefaultLocation_Concurrent(t *testing.T) {
+	defer SetDefaultLocation(nil)
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 1000; i++ {
+			SetDefaultLocation(WIT)
+			SetDefaultLocation(WIB)
+		}
+		close(done)
+	}()
+	for i := 0; i < 1000; i++ {
+		_ = FormatLocal(time.Now(), LayoutDB)
+	}
+	<-done
+}
+
+func TestAssumeWIB(t *testing.T) {
+	// Naive DB value decoded as UTC, but it is really WIB wall clock
+	naive := time.Date(2025, 1, 2, 10, 0, 0, 500, time.UTC)
+
+	assumed := AssumeWIB(naive)
+	assert.Equal(t, "Asia/Jakarta", assumed.Location().String())
+	assert.Equal(t, 10, assumed.Hour())
+	assert.Equal(t, 500, assumed.Nanosecond())
+	assert.Equal(t, 7*time.Hour, naive.Sub(assumed)) // instant moved back 7h
+
+	// ToWIB keeps the instant instead
+	converted := ToWIB(naive)
+	assert.Equal(t, 17, converted.Hour())
+	assert.True(t, converted.Equal(naive))
+}
+
+func TestAssumeUTC(t *testing.T) {
+	wib := time.Date(2025, 1, 2, 10, 0, 0, 0, WIB)
+
+	assumed := AssumeUTC(wib)
+	assert.Equal(t, "UTC", assumed.Location().String())
+	assert.Equal(t, 10, assumed.Hour())
+	assert.Equal(t, 7*time.Hour, assumed.Sub(wib))
+}
+
+func TestStringToDateID(t *testing.T) {
+	got, err := StringToDateID(" 31/12/2025 ", WIB)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 12, 31, 0, 0, 0, 0, WIB), got)
+
+	// Day-first: 02/01 is 2 January, not 1 February
+	got, err = StringToDateID("02/01/2025", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, time.January, got.Month())
+	assert.Equal(t, 2, got.Day())
+	assert.Equal(t, WIB, got.Location())
+
+	for _, bad := range []string{"", "2025-12-31", "31-12-2025", "13/13/2025"} {
+		_, err := StringToDateID(bad, WIB)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestStringToDateTimeID(t *testing.T) {
+	got, err := StringToDateTimeID("31-12-2025 14:30", UTC)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 12, 31, 14, 30, 0, 0, time.UTC), got)
+
+	_, err = StringToDateTimeID("31/12/2025 14:30", UTC)
+	assert.ErrorContains(t, err, "DD-MM-YYYY HH:MM")
+}
+
+func TestToDateIDString(t *testing.T) {
+	utcTime := time.Date(2025, 12, 31, 20, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, "01/01/2026", ToDateIDString(utcTime, WIB)) // crosses midnight in WIB
+	assert.Equal(t, "31/12/2025", ToDateIDString(utcTime, UTC))
+	assert.Equal(t, "01-01-2026 03:30", ToDateTimeIDString(utcTime, nil))
+	assert.Equal(t, "", ToDateIDString(time.Time{}, WIB))
+	assert.Equal(t, "", ToDateTimeIDString(time.Time{}, WIB))
+}
+
+func TestParseWithZoneName(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		offset int
+	}{
+		{"IANA name", "2025-01-02 15:04:05 Asia/Jakarta", 7 * 3600},
+		{"IANA name east", "2025-01-02 15:04:05 Asia/Jayapura", 9 * 3600},
+		{"abbreviation WITA", "2025-01-02 15:04:05 WITA", 8 * 3600},
+		{"abbreviation WIB", "2025-01-02 15:04:05 WIB", 7 * 3600},
+		{"UTC", "2025-01-02 15:04:05 UTC", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseWithZoneName(tt.input)
+			assert.NoError(t, err)
+			_, offset := got.Zone()
+			assert.Equal(t, tt.offset, offset)
+			assert.Equal(t, "2025-01-02 15:04:05", got.Format(LayoutDB))
+		})
+	}
+
+	_, err := ParseWithZoneName("2025-01-02 15:04:05 Mars/Olympus")
+	assert.ErrorContains(t, err, `unknown time zone "Mars/Olympus"`)
+
+	_, err = ParseWithZoneName("2025-01-02T15:04:05 Asia/Jakarta")
+	assert.ErrorContains(t, err, "expected YYYY-MM-DD HH:MM:SS <zone>")
+
+	_, err = ParseWithZoneName("2025-01-02")
+	assert.Error(t, err)
+}
+
+func TestTruncateToRoundTo(t *testing.T) {
+	at := time.Date(2025, 3, 10, 10, 44, 30, 0, WIB)
+
+	tests := []struct {
+		d         time.Duration
+		truncated string
+		rounded   string
+	}{
+		{5 * time.Minute, "10:40", "10:45"},
+		{15 * time.Minute, "10:30", "10:45"},
+		{30 * time.Minute, "10:30", "10:30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.d.String(), func(t *testing.T) {
+			assert.Equal(t, tt.truncated, TruncateTo(at, tt.d, WIB).Format("15:04"))
+			assert.Equal(t, tt.rounded, RoundTo(at, tt.d, WIB).Format("15:04"))
+			assert.Equal(t, WIB, TruncateTo(at, tt.d, WIB).Location())
+		})
+	}
+
+	// Input in UTC is bucketed on the WIB wall clock
+	utc := time.Date(2025, 3, 10, 3, 44, 30, 0, time.UTC) // 10:44:30 WIB
+	assert.Equal(t, "2025-03-10 10:30:00", TruncateTo(utc, 15*time.Minute, nil).Format(LayoutDB))
+
+	// Daily buckets align to local midnight, not UTC midnight
+	assert.Equal(t, "2025-03-10 00:00:00", TruncateTo(utc, 24*time.Hour, WIB).Format(LayoutDB))
+
+	// Non-whole-hour offset (India, +05:30): hourly buckets start on the local hour
+	ist := time.FixedZone("IST", 5*3600+30*60)
+	assert.Equal(t, "10:00", TruncateTo(time.Date(2025, 3, 10, 10, 20, 0, 0, ist), time.Hour, ist).Format("15:04"))
+
+	// Invalid duration returns t in loc
+	assert.Equal(t, at, TruncateTo(at, 0, WIB))
+}
+
+func TestExpiry(t *testing.T) {
+	defer ResetClock()
+	fixed := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	SetClock(func() time.Time { return fixed })
+
+	future := fixed.Add(90*time.Second + 500*time.Millisecond)
+	past := fixed.Add(-time.Minute)
+
+	assert.Equal(t, 90*time.Second+500*time.Millisecond, TimeUntil(future))
+	assert.Equal(t, int64(90), SecondsUntil(future))
+	assert.False(t, IsExpired(future))
+
+	assert.Equal(t, time.Duration(0), TimeUntil(past))
+	assert.Equal(t, int64(0), SecondsUntil(past))
+	assert.True(t, IsExpired(past))
+	assert.True(t, IsExpired(fixed)) // expires exactly now
+
+	// Zero time never expires
+	assert.Equal(t, NoExpiry, TimeUntil(time.Time{}))
+	assert.Equal(t, int64(-1), SecondsUntil(time.Time{}))
+	assert.False(t, IsExpired(time.Time{}))
+}
+
+func TestNormalize(t *testing.T) {
+	// time.Now carries a monotonic reading, which breaks == comparisons
+	n := time.Now()
+	norm := Normalize(n)
+	assert.Equal(t, 0, norm.Nanosecond())
+	assert.Equal(t, n.Location(), norm.Location())
+	assert.NotContains(t, norm.String(), "m=")
+
+	wib := time.Date(2025, 3, 1, 10, 30, 15, 999_999_999, WIB)
+	assert.Equal(t, time.Date(2025, 3, 1, 10, 30, 15, 0, WIB), Normalize(wib))
+}
+
+func TestEqualSecond(t *testing.T) {
+	a := time.Date(2025, 3, 1, 3, 30, 15, 123_000_000, time.UTC)
+	b := time.Date(2025, 3, 1, 10, 30, 15, 987_000_000, WIB) // same second, other zone
+	c := a.Add(time.Second)
+
+	assert.True(t, EqualSecond(a, b))
+	assert.False(t, EqualSecond(a, c))
+
+	// JSON round trip keeps the instant but drops the monotonic reading
+	n := time.Now()
+	var decoded time.Time
+	raw, _ := n.MarshalJSON()
+	assert.NoError(t, decoded.UnmarshalJSON(raw))
+	assert.True(t, EqualSecond(n, decoded))
+}
+
+func TestEpochConversion(t *testing.T) {
+	want := time.Date(2025, 1, 1, 0, 0, 0, 123_456_789, time.UTC)
+
+	assert.Equal(t, want.Truncate(time.Second), FromUnix(1735689600))
+	assert.Equal(t, want.Truncate(time.Millisecond), FromUnixMillis(1735689600123))
+	assert.Equal(t, want.Truncate(time.Microsecond), FromUnixMicros(1735689600123456))
+	assert.Equal(t, want, FromUnixNanos(1735689600123456789))
+	assert.Equal(t, time.UTC, FromUnixMillis(0).Location())
+
+	// Inverses, from any zone
+	local := want.In(WIB)
+	assert.Equal(t, int64(1735689600), ToUnix(local))
+	assert.Equal(t, int64(1735689600123), ToUnixMillis(local))
+	assert.Equal(t, int64(1735689600123456), ToUnixMicros(local))
+	assert.Equal(t, int64(1735689600123456789), ToUnixNanos(local))
+
+	// Pre-epoch values round-trip
+	assert.Equal(t, int64(-1500), ToUnixMillis(FromUnixMillis(-1500)))
+
+	// Zero time maps to 0
+	assert.Equal(t, int64(0), ToUnix(time.Time{}))
+	assert.Equal(t, int64(0), ToUnixMillis(time.Time{}))
+	assert.Equal(t, int64(0), ToUnixMicros(time.Time{}))
+	assert.Equal(t, int64(0), ToUnixNanos(time.Time{}))
+}
+
+func TestParseClock(t *testing.T) {
+	valid := map[string]time.Duration{
+		"09:00":    9 * time.Hour,
+		"9:00":     9 * time.Hour,
+		"17:30":    17*time.Hour + 30*time.Minute,
+		"00:00":    0,
+		"23:59:59": 23*time.Hour + 59*time.Minute + 59*time.Second,
+		" 08:15 ":  8*time.Hour + 15*time.Minute,
+	}
+	for input, expected := range valid {
+		got, err := ParseClock(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, got, input)
+	}
+
+	invalid := []string{"", "9", "24:00", "12:60", "12:30:60", "12:5", "123:00", "-1:00", "+9:00", "ab:cd", "12:30:00:00"}
+	for _, input := range invalid {
+		_, err := ParseClock(input)
+		assert.Error(t, err, input)
+	}
+
+	_, err := ParseClock("24:00")
+	assert.EqualError(t, err, `invalid clock "24:00": hour must be 0-23`)
+}
+
+func TestApplyClock(t *testing.T) {
+	clock := 17*time.Hour + 30*time.Minute
+
+	// Date is read in loc: 20:00 UTC on Jan 1 is already Jan 2 in WIB
+	date := time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, 1, 2, 17, 30, 0, 0, WIB), ApplyClock(date, clock, WIB))
+	assert.Equal(t, time.Date(2025, 1, 1, 17, 30, 0, 0, time.UTC), ApplyClock(date, clock, time.UTC))
+
+	// nil uses DefaultLocation (WIB)
+	assert.Equal(t, time.Date(2025, 1, 2, 17, 30, 0, 0, WIB), ApplyClock(date, clock, nil))
+
+	// Sub-second precision and rollover past midnight
+	assert.Equal(t, time.Date(2025, 1, 3, 1, 0, 0, 500, WIB), ApplyClock(date, 25*time.Hour+500, WIB))
+
+	// Wall clock is kept across a DST change
+	ny, err := time.LoadLocation("America/New_York")
+	if err == nil {
+		dstDay := time.Date(2025, 3, 9, 12, 0, 0, 0, ny) // clocks jump 02:00 → 03:00
+		got := ApplyClock(dstDay, 9*time.Hour, ny)
+		assert.Equal(t, 9, got.Hour())
+		assert.Equal(t, 9, got.Day())
+	}
+}
+
+func TestWithinBusinessHours(t *testing.T) {
+	at := func(h, m int) time.Time { return time.Date(2025, 1, 6, h, m, 0, 0, WIB) }
+	nine, five := 9*time.Hour, 17*time.Hour
+
+	assert.True(t, WithinBusinessHours(at(9, 0), nine, five, WIB)) // open is inclusive
+	assert.True(t, WithinBusinessHours(at(16, 59), nine, five, WIB))
+	assert.False(t, WithinBusinessHours(at(17, 0), nine, five, WIB)) // close is exclusive
+	assert.False(t, WithinBusinessHours(at(8, 59), nine, five, WIB))
+
+	// Overnight 22:00–02:00
+	ten, two := 22*time.Hour, 2*time.Hour
+	assert.True(t, WithinBusinessHours(at(23, 30), ten, two, WIB))
+	assert.True(t, WithinBusinessHours(at(1, 0), ten, two, WIB))
+	assert.False(t, WithinBusinessHours(at(2, 0), ten, two, WIB))
+	assert.False(t, WithinBusinessHours(at(12, 0), ten, two, WIB))
+
+	// Read in loc: 03:00 UTC is 10:00 WIB; nil uses DefaultLocation
+	utc := time.Date(2025, 1, 6, 3, 0, 0, 0, time.UTC)
+	assert.True(t, WithinBusinessHours(utc, nine, five, WIB))
+	assert.True(t, WithinBusinessHours(utc, nine, five, nil))
+	assert.False(t, WithinBusinessHours(utc, nine, five, time.UTC))
+
+	// Equal bounds are never open; 0–24h is always open
+	assert.False(t, WithinBusinessHours(at(9, 0), nine, nine, WIB))
+	assert.True(t, WithinBusinessHours(at(23, 59), 0, 24*time.Hour, WIB))
+}
+
+func TestWithinSchedule(t *testing.T) {
+	schedule := map[time.Weekday][2]time.Duration{
+		time.Monday: {9 * time.Hour, 17 * time.Hour},
+		time.Friday: {22 * time.Hour, 2 * time.Hour},
+	}
+	// 2025-01-06 is a Monday, 2025-01-10 a Friday
+	at := func(day, h int) time.Time { return time.Date(2025, 1, day, h, 0, 0, 0, WIB) }
+
+	assert.True(t, WithinSchedule(at(6, 10), schedule, WIB))
+	assert.False(t, WithinSchedule(at(6, 18), schedule, WIB))
+	assert.False(t, WithinSchedule(at(7, 10), schedule, WIB)) // Tuesday closed
+
+	// Friday night runs into Saturday, which has no entry of its own
+	assert.True(t, WithinSchedule(at(10, 23), schedule, WIB))
+	assert.True(t, WithinSchedule(at(11, 1), schedule, WIB))
+	assert.False(t, WithinSchedule(at(11, 2), schedule, WIB))
+	assert.False(t, WithinSchedule(at(10, 1), schedule, WIB)) // Friday early morning: Thursday closed
+
+	// Sunday night wraps to Monday morning via the weekday index
+	sunday := map[time.Weekday][2]time.Duration{time.Sunday: {20 * time.Hour, 3 * time.Hour}}
+	assert.True(t, WithinSchedule(at(6, 2), sunday, WIB))
+	assert.True(t, WithinSchedule(at(5, 21), sunday, nil))
+
+	assert.False(t, WithinSchedule(at(6, 10), nil, WIB))
+}
@@ -0,0 +1,135 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// TIME RANGES
+// =============================================================================
+
+// TimeRange is a half-open time window [Start, End).
+//
+//   - A zero End means the range is open-ended (unbounded into the future).
+//   - A range whose End precedes Start is invalid: it contains nothing,
+//     overlaps nothing, and has zero duration.
+//
+// Example:
+//
+//	shift := format.TimeRange{Start: nine, End: five}
+//	shift.Contains(time.Now())
+type TimeRange struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// IsOpen reports whether the range has no end.
+func (r TimeRange) IsOpen() bool {
+	return r.End.IsZero()
+}
+
+// IsValid reports whether the range is open-ended or End is not before Start.
+func (r TimeRange) IsValid() bool {
+	return r.IsOpen() || !r.End.Before(r.Start)
+}
+
+// Contains reports whether t falls within [Start, End).
+// Returns false for invalid ranges.
+func (r TimeRange) Contains(t time.Time) bool {
+	if !r.IsValid() || t.Before(r.Start) {
+		return false
+	}
+	return r.IsOpen() || t.Before(r.End)
+}
+
+// Overlaps reports whether the two ranges share at least one instant.
+// Touching ranges (one ends exactly when the other starts) do not overlap.
+// Returns false if either range is invalid.
+func (r TimeRange) Overlaps(other TimeRange) bool {
+	if !r.IsValid() || !other.IsValid() {
+		return false
+	}
+	// r must start before other ends, and other must start before r ends
+	rStartsFirst := other.IsOpen() || r.Start.Before(other.End)
+	otherStartsFirst := r.IsOpen() || other.Start.Before(r.End)
+	return rStartsFirst && otherStartsFirst
+}
+
+// Duration returns End - Start.
+// Returns 0 for open-ended or invalid ranges.
+func (r TimeRange) Duration() time.Duration {
+	if r.IsOpen() || !r.IsValid() {
+		return 0
+	}
+	return r.End.Sub(r.Start)
+}
+
+// FormatRange formats an event time range for display in loc (nil = DefaultLocation),
+// ending with the zone abbreviation. The date is written once when start and end
+// fall on the same local day, and for both ends otherwise (with the year only
+// when the years differ). A zero end is open-ended; a zero start returns "".
+//
+// Example:
+//
+//	FormatRange(start, end, format.WIB)
+//	// same day:   "2 Jan 2025, 14:00 – 16:00 WIB"
+//	// cross-day:  "2 Jan 14:00 – 3 Jan 02:00 WIB"
+//	// cross-year: "31 Dec 2024, 22:00 – 1 Jan 2025, 02:00 WIB"
+//	// open end:   "2 Jan 2025, 14:00 WIB –"
+func FormatRange(start, end time.Time, loc *time.Location) string {
+	if start.IsZero() {
+		return ""
+	}
+	if loc == nil {
+		loc = defaultLocation()
+	}
+	start = start.In(loc)
+	zone := zoneAbbreviation(start)
+
+	if end.IsZero() {
+		return start.Format("2 Jan 2006, 15:04") + " " + zone + " –"
+	}
+	end = end.In(loc)
+
+	sy, sm, sd := start.Date()
+	ey, em, ed := end.Date()
+	switch {
+	case sy == ey && sm == em && sd == ed:
+		return start.Format("2 Jan 2006, 15:04") + " – " + end.Format("15:04") + " " + zone
+	case sy == ey:
+		return start.Format("2 Jan 15:04") + " – " + end.Format("2 Jan 15:04") + " " + zone
+	default:
+		return start.Format("2 Jan 2006, 15:04") + " – " + end.Format("2 Jan 2006, 15:04") + " " + zone
+	}
+}
+
+// zoneAbbreviation returns the display abbreviation of t's zone, e.g. "WIB".
+// The package's fixed zones are named after IANA locations, so they are
+// mapped back to their Indonesian abbreviations; unnamed zones become "UTC+7".
+func zoneAbbreviation(t time.Time) string {
+	name, offset := t.Zone()
+	switch name {
+	case "Asia/Jakarta", "Asia/Pontianak":
+		return "WIB"
+	case "Asia/Makassar":
+		return "WITA"
+	case "Asia/Jayapura":
+		return "WIT"
+	}
+
+	// Names like "Asia/Bangkok" (fixed zones) or "+07" (tzdata without an abbreviation)
+	if name == "" || strings.Contains(name, "/") || name[0] == '+' || name[0] == '-' {
+		sign := "+"
+		if offset < 0 {
+			sign, offset = "-", -offset
+		}
+		hours, minutes := offset/3600, (offset%3600)/60
+		if minutes != 0 {
+			return fmt.Sprintf("UTC%s%d:%02d", sign, hours, minutes)
+		}
+		return fmt.Sprintf("UTC%s%d", sign, hours)
+	}
+	return name
+}
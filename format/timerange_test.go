@@ -0,0 +1,88 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeRange(t *testing.T) {
+	at := func(h int) time.Time { return time.Date(2025, 1, 2, h, 0, 0, 0, WIB) }
+
+	closed := TimeRange{Start: at(9), End: at(17)}
+	open := TimeRange{Start: at(12)}
+	invalid := TimeRange{Start: at(17), End: at(9)}
+
+	t.Run("Contains", func(t *testing.T) {
+		assert.True(t, closed.Contains(at(9)))
+		assert.True(t, closed.Contains(at(16)))
+		assert.False(t, closed.Contains(at(17))) // end is exclusive
+		assert.False(t, closed.Contains(at(8)))
+
+		assert.True(t, open.Contains(at(23)))
+		assert.False(t, open.Contains(at(11)))
+
+		assert.False(t, invalid.Contains(at(12)))
+	})
+
+	t.Run("Overlaps", func(t *testing.T) {
+		assert.True(t, closed.Overlaps(TimeRange{Start: at(16), End: at(20)}))
+		assert.True(t, closed.Overlaps(TimeRange{Start: at(10), End: at(11)}))
+		assert.False(t, closed.Overlaps(TimeRange{Start: at(17), End: at(20)})) // touching
+		assert.True(t, closed.Overlaps(open))
+		assert.True(t, open.Overlaps(closed))
+		assert.False(t, TimeRange{Start: at(18)}.Overlaps(closed))
+		assert.True(t, open.Overlaps(TimeRange{Start: at(20)}))
+		assert.False(t, closed.Overlaps(invalid))
+	})
+
+	t.Run("Duration", func(t *testing.T) {
+		assert.Equal(t, 8*time.Hour, closed.Duration())
+		assert.Equal(t, time.Duration(0), open.Duration())
+		assert.Equal(t, time.Duration(0), invalid.Duration())
+	})
+
+	t.Run("Validity", func(t *testing.T) {
+		assert.True(t, closed.IsValid())
+		assert.True(t, open.IsValid())
+		assert.True(t, open.IsOpen())
+		assert.False(t, invalid.IsValid())
+	})
+}
+
+func TestFormatRange(t *testing.T) {
+	at := func(y int, m time.Month, d, h int, loc *time.Location) time.Time {
+		return time.Date(y, m, d, h, 0, 0, 0, loc)
+	}
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		loc        *time.Location
+		expected   string
+	}{
+		{"same day", at(2025, 1, 2, 14, WIB), at(2025, 1, 2, 16, WIB), WIB, "2 Jan 2025, 14:00 – 16:00 WIB"},
+		{"cross-day", at(2025, 1, 2, 14, WIB), at(2025, 1, 3, 2, WIB), WIB, "2 Jan 14:00 – 3 Jan 02:00 WIB"},
+		{"cross-year", at(2024, 12, 31, 22, WIB), at(2025, 1, 1, 2, WIB), WIB, "31 Dec 2024, 22:00 – 1 Jan 2025, 02:00 WIB"},
+		{"open-ended", at(2025, 1, 2, 14, WIB), time.Time{}, WIB, "2 Jan 2025, 14:00 WIB –"},
+		{"converted to loc", at(2025, 1, 2, 7, time.UTC), at(2025, 1, 2, 9, time.UTC), WIB, "2 Jan 2025, 14:00 – 16:00 WIB"},
+		{"same UTC day, different local days", at(2025, 1, 2, 16, time.UTC), at(2025, 1, 2, 18, time.UTC), WIB, "2 Jan 23:00 – 3 Jan 01:00 WIB"},
+		{"WITA", at(2025, 1, 2, 14, WITA), at(2025, 1, 2, 16, WITA), WITA, "2 Jan 2025, 14:00 – 16:00 WITA"},
+		{"UTC", at(2025, 1, 2, 14, time.UTC), at(2025, 1, 2, 16, time.UTC), time.UTC, "2 Jan 2025, 14:00 – 16:00 UTC"},
+		{"unnamed zone", at(2025, 1, 2, 14, Bangkok), at(2025, 1, 2, 16, Bangkok), Bangkok, "2 Jan 2025, 14:00 – 16:00 UTC+7"},
+		{"zero start", time.Time{}, at(2025, 1, 2, 16, WIB), WIB, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FormatRange(tt.start, tt.end, tt.loc))
+		})
+	}
+
+	// Partial-hour offsets
+	newfoundland := time.FixedZone("", -(3*3600 + 1800))
+	assert.Equal(t, "2 Jan 2025, 14:00 – 16:00 UTC-3:30", FormatRange(at(2025, 1, 2, 14, newfoundland), at(2025, 1, 2, 16, newfoundland), newfoundland))
+	india := time.FixedZone("", 5*3600+1800)
+	assert.Equal(t, "2 Jan 2025, 14:00 – 16:00 UTC+5:30", FormatRange(at(2025, 1, 2, 14, india), at(2025, 1, 2, 16, india), india))
+}
@@ -0,0 +1,26 @@
+package response
+
+import (
+	"context"
+
+	"github.com/Jkenyut/nvx-go-helper/activity"
+)
+
+// AuditEntry builds the audit-log record for a request that produced r.
+// Who and where come from ctx (see activity.NewAuditEntry); status code,
+// success, and error code come from r.Meta. The request ID falls back to
+// Meta.RequestID, so entries match the ID the client saw even when it was generated.
+//
+// Example:
+//
+//	resp := response.NoContent(ctx)
+//	auditLogger.Info("audit", "entry", response.AuditEntry(ctx, "user.delete", resp))
+//	response.Write(w, resp)
+func AuditEntry(ctx context.Context, action string, r Response) activity.AuditEntry {
+	entry := activity.NewAuditEntry(ctx, action, r.Meta.StatusCode, r.Meta.Success)
+	entry.ErrorCode = r.Meta.ErrorCode
+	if entry.RequestID == "" {
+		entry.RequestID = r.Meta.RequestID
+	}
+	return entry
+}
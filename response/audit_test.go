@@ -0,0 +1,27 @@
+package response
+
+import (
+	"testing"
+
+	"github.com/Jkenyut/nvx-go-helper/activity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditEntry(t *testing.T) {
+	ctx := activity.WithUserID(activity.NewContext("transfer"), "user-42")
+	resp := UnprocessableEntity(ctx, "insufficient balance").WithErrorCode("insufficient_balance")
+
+	entry := AuditEntry(ctx, "transfer.create", resp)
+
+	assert.Equal(t, "transfer.create", entry.Action)
+	assert.Equal(t, "user-42", entry.UserID)
+	assert.Equal(t, 422, entry.StatusCode)
+	assert.False(t, entry.Success)
+	assert.Equal(t, "insufficient_balance", entry.ErrorCode)
+	// Generated request ID matches the one sent to the client
+	assert.Equal(t, resp.Meta.RequestID, entry.RequestID)
+
+	// Context request ID wins
+	ctx = activity.WithRequestID(ctx, "req-ctx")
+	assert.Equal(t, "req-ctx", AuditEntry(ctx, "transfer.create", OK(ctx, "ok", nil)).RequestID)
+}
@@ -0,0 +1,36 @@
+package response
+
+import (
+	"fmt"
+
+	"github.com/Jkenyut/nvx-go-helper/cryptoutil"
+)
+
+// JSONMarshalCanonical serializes the response into byte-stable JSON for
+// HMAC signing of webhook payloads. Equal responses always produce equal bytes,
+// whether Data holds a struct or the equivalent map.
+//
+// Canonicalization applied:
+//   - The whole response (Meta and Data) is round-tripped through JSON, so every
+//     object becomes a map and keys are sorted by byte order at every depth.
+//   - No insignificant whitespace and no trailing newline.
+//   - HTML characters (<, >, &) are NOT escaped, unlike json.Marshal.
+//   - Numbers keep the literal produced by encoding/json (no float64 rounding).
+//     This is not RFC 8785 (JCS) number normalization, so sign and verify with
+//     this function on both ends rather than another JCS implementation.
+//
+// Returns an error if Data cannot be serialized.
+//
+// Example:
+//
+//	body, err := resp.JSONMarshalCanonical()
+//	mac := hmac.New(sha256.New, secret)
+//	mac.Write(body)
+//	signature := hex.EncodeToString(mac.Sum(nil))
+func (r Response) JSONMarshalCanonical() ([]byte, error) {
+	b, err := cryptoutil.CanonicalJSON(r)
+	if err != nil {
+		return nil, fmt.Errorf("response: data is not JSON-serializable: %w", err)
+	}
+	return b, nil
+}
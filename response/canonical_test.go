@@ -0,0 +1,37 @@
+package response
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Jkenyut/nvx-go-helper/activity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONMarshalCanonical(t *testing.T) {
+	ctx := activity.WithRequestID(context.Background(), "req-sign")
+
+	type payment struct {
+		Zeta   string `json:"zeta"`
+		Amount int64  `json:"amount"`
+		Note   string `json:"note"`
+	}
+
+	t.Run("Struct and map produce identical bytes", func(t *testing.T) {
+		fromStruct, err := OK(ctx, "ok", payment{Zeta: "z", Amount: 9007199254740993, Note: "a<b&c"}).JSONMarshalCanonical()
+		assert.NoError(t, err)
+
+		fromMap, err := OK(ctx, "ok", map[string]any{"note": "a<b&c", "amount": int64(9007199254740993), "zeta": "z"}).JSONMarshalCanonical()
+		assert.NoError(t, err)
+
+		assert.Equal(t, string(fromStruct), string(fromMap))
+		assert.Equal(t,
+			`{"data":{"amount":9007199254740993,"note":"a<b&c","zeta":"z"},"meta":{"message":"ok","request_id":"req-sign","status_code":200,"success":true}}`,
+			string(fromStruct))
+	})
+
+	t.Run("Unserializable data", func(t *testing.T) {
+		_, err := OK(ctx, "ok", make(chan int)).JSONMarshalCanonical()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,146 @@
+package response
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Jkenyut/nvx-go-helper/activity"
+	"github.com/Jkenyut/nvx-go-helper/worker"
+)
+
+// maxRetryAfter caps how long Do honors a server's Retry-After.
+const maxRetryAfter = 30 * time.Second
+
+// retryBackoff is the delay before retry n when the server sends no Retry-After.
+var retryBackoff = worker.ExponentialBackoff(100*time.Millisecond, 5*time.Second, 2, true)
+
+// Do sends req with client (nil = http.DefaultClient) and decodes the body into
+// a Response, the client counterpart of Write for service-to-service calls.
+//
+// Behavior:
+//   - req is cloned with ctx, and activity.InjectHeaders propagates the request ID.
+//   - 502, 503, and 504 are retried up to maxRetries times, waiting for Retry-After
+//     (seconds or HTTP date, capped at 30s) or else an exponential backoff with jitter.
+//   - A request body is replayed via req.GetBody, which http.NewRequest sets for
+//     bytes and strings readers; without it the request is sent only once.
+//   - An empty body (e.g. 204) yields a Meta built from the HTTP status.
+//
+// The error is nil whenever an envelope was obtained, including 4xx/5xx ones:
+// use resp.AsError() for those. It is non-nil for transport failures, ctx
+// cancellation, and bodies that are not JSON (e.g. a proxy's HTML error page).
+//
+// Example:
+//
+//	req, _ := http.NewRequest(http.MethodGet, "http://users/users/"+id, nil)
+//	resp, err := response.Do(ctx, httpClient, req, 2)
+//	if err == nil {
+//	    err = resp.AsError()
+//	}
+func Do(ctx context.Context, client *http.Client, req *http.Request, maxRetries int) (Response, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+	// A consumed body cannot be sent again
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		maxRetries = 0
+	}
+
+	for attempt := 0; ; attempt++ {
+		out := req.Clone(ctx)
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return Response{}, fmt.Errorf("response: reset request body: %w", err)
+			}
+			out.Body = body
+		}
+		activity.InjectHeaders(ctx, out.Header)
+
+		httpResp, err := client.Do(out)
+		if err != nil {
+			return Response{}, fmt.Errorf("response: send request: %w", err)
+		}
+
+		if attempt < maxRetries && isRetryableStatus(httpResp.StatusCode) {
+			delay := retryDelay(httpResp.Header.Get("Retry-After"), attempt)
+			// Drain so the connection can be reused
+			_, _ = io.Copy(io.Discard, httpResp.Body)
+			httpResp.Body.Close()
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return Response{}, ctx.Err()
+			case <-timer.C:
+			}
+			continue
+		}
+
+		resp, err := decodeResponse(httpResp)
+		httpResp.Body.Close()
+		return resp, err
+	}
+}
+
+// isRetryableStatus reports whether status signals a transient upstream failure.
+func isRetryableStatus(status int) bool {
+	return status == http.StatusBadGateway ||
+		status == http.StatusServiceUnavailable ||
+		status == http.StatusGatewayTimeout
+}
+
+// retryDelay returns the wait before the next attempt: the Retry-After value
+// when it is valid, otherwise retryBackoff(attempt).
+func retryDelay(retryAfter string, attempt int) time.Duration {
+	if retryAfter != "" {
+		var delay time.Duration
+		if secs, err := strconv.Atoi(retryAfter); err == nil && secs >= 0 {
+			delay = time.Duration(secs) * time.Second
+		} else if at, err := http.ParseTime(retryAfter); err == nil {
+			delay = max(time.Until(at), 0)
+		} else {
+			return retryBackoff(attempt)
+		}
+		return min(delay, maxRetryAfter)
+	}
+	return retryBackoff(attempt)
+}
+
+// decodeResponse reads an envelope from httpResp. An empty body becomes a Meta
+// derived from the HTTP status; a missing status_code is filled in the same way.
+func decodeResponse(httpResp *http.Response) (Response, error) {
+	status := httpResp.StatusCode
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return Response{}, fmt.Errorf("response: read body (status %d): %w", status, err)
+	}
+
+	var resp Response
+	if len(bytes.TrimSpace(body)) == 0 {
+		resp.Meta = Meta{
+			Success:    status >= 200 && status < 300,
+			Message:    DefaultMessage(status),
+			StatusCode: status,
+		}
+	} else if err := json.Unmarshal(body, &resp); err != nil {
+		return Response{}, fmt.Errorf("response: decode body (status %d): %w", status, err)
+	}
+
+	if resp.Meta.StatusCode == 0 {
+		resp.Meta.StatusCode = status
+	}
+	if resp.Meta.RequestID == "" && RequestIDHeader != "" {
+		resp.Meta.RequestID = httpResp.Header.Get(RequestIDHeader)
+	}
+	return resp, nil
+}
@@ -0,0 +1,144 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Jkenyut/nvx-go-helper/activity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDo(t *testing.T) {
+	ctx := activity.WithRequestID(context.Background(), "req-123")
+
+	t.Run("Decodes envelope and propagates request ID", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "req-123", r.Header.Get("X-Request-ID"))
+			Write(w, NotFound(r.Context(), "user not found").WithErrorCode("user_not_found"))
+		}))
+		defer srv.Close()
+
+		req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+		resp, err := Do(ctx, srv.Client(), req, 2)
+		require.NoError(t, err)
+		assert.Equal(t, 404, resp.Meta.StatusCode)
+		assert.Equal(t, "user_not_found", resp.Meta.ErrorCode)
+		assert.True(t, errors.Is(resp.AsError(), &APIError{StatusCode: 404}))
+		assert.Empty(t, req.Header.Get("X-Request-ID"), "caller's request is not mutated")
+	})
+
+	t.Run("Retries 503 honoring Retry-After and replays the body", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, `{"amount":100}`, string(body))
+			if calls.Add(1) < 3 {
+				w.Header().Set("Retry-After", "0")
+				Write(w, ServiceUnavailable(r.Context(), "try later"))
+				return
+			}
+			Write(w, Created(r.Context(), "paid", map[string]int{"amount": 100}))
+		}))
+		defer srv.Close()
+
+		req, _ := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(`{"amount":100}`))
+		resp, err := Do(ctx, nil, req, 2)
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), calls.Load())
+		assert.Equal(t, 201, resp.Meta.StatusCode)
+		assert.Equal(t, map[string]any{"amount": float64(100)}, resp.Data)
+	})
+
+	t.Run("Returns the last 5xx once retries run out", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.Header().Set("Retry-After", "0")
+			Write(w, GatewayTimeout(r.Context(), "upstream timeout"))
+		}))
+		defer srv.Close()
+
+		req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+		resp, err := Do(ctx, nil, req, 1)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), calls.Load())
+		assert.Equal(t, 504, resp.Meta.StatusCode)
+	})
+
+	t.Run("500 is not retried", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			Write(w, InternalError(r.Context()))
+		}))
+		defer srv.Close()
+
+		req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+		resp, err := Do(ctx, nil, req, 3)
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), calls.Load())
+		assert.Equal(t, 500, resp.Meta.StatusCode)
+	})
+
+	t.Run("Empty body and non-JSON body", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/html" {
+				w.WriteHeader(http.StatusBadGateway)
+				_, _ = io.WriteString(w, "<html>bad gateway</html>")
+				return
+			}
+			w.Header().Set("X-Request-ID", "srv-1")
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer srv.Close()
+
+		req, _ := http.NewRequest(http.MethodDelete, srv.URL, nil)
+		resp, err := Do(ctx, nil, req, 0)
+		require.NoError(t, err)
+		assert.True(t, resp.Meta.Success)
+		assert.Equal(t, 204, resp.Meta.StatusCode)
+		assert.Equal(t, "srv-1", resp.Meta.RequestID)
+
+		req, _ = http.NewRequest(http.MethodGet, srv.URL+"/html", nil)
+		_, err = Do(ctx, nil, req, 0)
+		assert.ErrorContains(t, err, "decode body (status 502)")
+	})
+
+	t.Run("Context cancellation stops waiting", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "10")
+			Write(w, ServiceUnavailable(r.Context(), "try later"))
+		}))
+		defer srv.Close()
+
+		cctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+		start := time.Now()
+		_, err := Do(cctx, nil, req, 3)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, 2*time.Second, retryDelay("2", 0))
+	assert.Equal(t, maxRetryAfter, retryDelay("3600", 0))
+	assert.Equal(t, time.Duration(0), retryDelay(time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0))
+
+	future := retryDelay(time.Now().Add(10*time.Second).UTC().Format(http.TimeFormat), 0)
+	assert.Greater(t, future, 5*time.Second)
+	assert.LessOrEqual(t, future, 10*time.Second)
+
+	// Invalid or missing values fall back to the jittered backoff
+	assert.LessOrEqual(t, retryDelay("soon", 0), 100*time.Millisecond)
+	assert.LessOrEqual(t, retryDelay("", 1), 200*time.Millisecond)
+}
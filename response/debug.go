@@ -0,0 +1,58 @@
+package response
+
+import (
+	"context"
+	"runtime/debug"
+	"strings"
+	"sync/atomic"
+)
+
+// includeDebug controls whether InternalErrorDebug attaches a debug block. Off by default.
+var includeDebug atomic.Bool
+
+// Debug carries internal error details for non-production environments.
+type Debug struct {
+	Error string   `json:"error"`           // err.Error(), including wrapped errors
+	Stack []string `json:"stack,omitempty"` // goroutine stack, one frame line per entry
+}
+
+// SetIncludeDebug enables or disables the "debug" block added by InternalErrorDebug.
+// Enable it only in local/staging environments: the block exposes internal error
+// messages and stack traces. Never enable it in production. Safe for concurrent use.
+//
+// Example:
+//
+//	response.SetIncludeDebug(os.Getenv("APP_ENV") != "production")
+func SetIncludeDebug(enabled bool) {
+	includeDebug.Store(enabled)
+}
+
+// IncludeDebug reports whether InternalErrorDebug attaches a debug block.
+func IncludeDebug() bool {
+	return includeDebug.Load()
+}
+
+// InternalErrorDebug sends a 500 Internal Server Error response like InternalError.
+// When SetIncludeDebug is enabled it also carries err's message and the current
+// stack in a "debug" block; otherwise the response is identical to InternalError
+// and the debug key is omitted entirely. A nil err adds only the stack.
+//
+// Example:
+//
+//	if err != nil {
+//	    response.Write(w, response.InternalErrorDebug(r.Context(), err))
+//	    return
+//	}
+func InternalErrorDebug(ctx context.Context, err error) Response {
+	resp := InternalError(ctx)
+	if !includeDebug.Load() {
+		return resp
+	}
+
+	d := &Debug{Stack: strings.Split(strings.TrimSpace(string(debug.Stack())), "\n")}
+	if err != nil {
+		d.Error = err.Error()
+	}
+	resp.Debug = d
+	return resp
+}
@@ -0,0 +1,46 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInternalErrorDebug(t *testing.T) {
+	ctx := context.Background()
+	err := fmt.Errorf("load user: %w", errors.New("connection refused"))
+
+	t.Run("Disabled by default", func(t *testing.T) {
+		assert.False(t, IncludeDebug())
+
+		resp := InternalErrorDebug(ctx, err)
+		assert.Equal(t, 500, resp.Meta.StatusCode)
+		assert.Equal(t, "internal server error", resp.Meta.Message)
+		assert.Nil(t, resp.Debug)
+
+		b, _ := json.Marshal(resp)
+		assert.NotContains(t, string(b), "debug")
+		assert.NotContains(t, string(b), "connection refused")
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		SetIncludeDebug(true)
+		defer SetIncludeDebug(false)
+
+		resp := InternalErrorDebug(ctx, err)
+		assert.Equal(t, 500, resp.Meta.StatusCode)
+		if assert.NotNil(t, resp.Debug) {
+			assert.Equal(t, "load user: connection refused", resp.Debug.Error)
+			assert.NotEmpty(t, resp.Debug.Stack)
+			assert.True(t, strings.HasPrefix(resp.Debug.Stack[0], "goroutine "))
+		}
+
+		b, _ := json.Marshal(resp)
+		assert.Contains(t, string(b), `"debug":{"error":"load user: connection refused"`)
+	})
+}
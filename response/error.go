@@ -0,0 +1,115 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// APIError is the error form of a failed Response, for Go clients of these APIs.
+type APIError struct {
+	StatusCode int    // HTTP status code
+	Message    string // meta.message
+	RequestID  string // meta.request_id, quote it when reporting issues
+	ErrorCode  string // meta.error_code, empty if the server did not set one
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	if e.ErrorCode != "" {
+		return fmt.Sprintf("api error %d (%s): %s [request_id=%s]", e.StatusCode, e.ErrorCode, e.Message, e.RequestID)
+	}
+	return fmt.Sprintf("api error %d: %s [request_id=%s]", e.StatusCode, e.Message, e.RequestID)
+}
+
+// Is reports whether target is an *APIError whose non-zero StatusCode and
+// ErrorCode match e. Zero fields in target act as wildcards, so callers can
+// match on status, code, or both.
+//
+// Example:
+//
+//	if errors.Is(err, &response.APIError{StatusCode: 404}) { ... }
+//	if errors.Is(err, &response.APIError{ErrorCode: "insufficient_balance"}) { ... }
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
+	if !ok {
+		return false
+	}
+	return (t.StatusCode == 0 || t.StatusCode == e.StatusCode) &&
+		(t.ErrorCode == "" || t.ErrorCode == e.ErrorCode)
+}
+
+// AsError converts a decoded Response into an error.
+// Returns nil when meta.success is true, otherwise an *APIError.
+//
+// Example:
+//
+//	var resp response.Response
+//	_ = json.NewDecoder(httpResp.Body).Decode(&resp)
+//	if err := resp.AsError(); err != nil {
+//	    return err
+//	}
+func (r Response) AsError() error {
+	if r.Meta.Success {
+		return nil
+	}
+	return &APIError{
+		StatusCode: r.Meta.StatusCode,
+		Message:    r.Meta.Message,
+		RequestID:  r.Meta.RequestID,
+		ErrorCode:  r.Meta.ErrorCode,
+	}
+}
+
+// StatusError is an error carrying the HTTP status and error code it should be
+// reported with, so service layers can decide the response without importing
+// handler code. Create one with NewError and turn it into a Response with FromError.
+type StatusError struct {
+	StatusCode int    // HTTP status code (4xx or 5xx)
+	ErrorCode  string // meta.error_code, may be empty
+	Message    string // meta.message, shown to clients
+}
+
+// NewError returns a *StatusError. Statuses outside 400-599 are replaced with 500,
+// since an error must never produce a success envelope.
+// Wrap it freely with fmt.Errorf("...: %w", err); FromError finds it via errors.As.
+//
+// Example:
+//
+//	if user == nil {
+//	    return nil, response.NewError(404, "user_not_found", "user not found")
+//	}
+func NewError(status int, code, message string) error {
+	if status < 400 || status > 599 {
+		status = 500
+	}
+	return &StatusError{StatusCode: status, ErrorCode: code, Message: message}
+}
+
+// Error implements the error interface.
+func (e *StatusError) Error() string {
+	if e.ErrorCode != "" {
+		return fmt.Sprintf("status %d (%s): %s", e.StatusCode, e.ErrorCode, e.Message)
+	}
+	return fmt.Sprintf("status %d: %s", e.StatusCode, e.Message)
+}
+
+// FromError converts err into an error Response. If err wraps a *StatusError,
+// its status, code, and message are used as-is. Any other error (including nil)
+// becomes a 500 via InternalErrorDebug, so internal messages never reach clients
+// unless SetIncludeDebug is enabled.
+//
+// Example:
+//
+//	user, err := svc.GetUser(ctx, id)
+//	if err != nil {
+//	    response.Write(w, response.FromError(ctx, err))
+//	    return
+//	}
+func FromError(ctx context.Context, err error) Response {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return WithMessage(ctx, statusErr.Message, statusErr.StatusCode).WithErrorCode(statusErr.ErrorCode)
+	}
+	return InternalErrorDebug(ctx, err)
+}
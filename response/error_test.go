@@ -0,0 +1,84 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Jkenyut/nvx-go-helper/activity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsError(t *testing.T) {
+	ctx := activity.WithRequestID(context.Background(), "req-123")
+
+	t.Run("Success returns nil", func(t *testing.T) {
+		assert.NoError(t, OK(ctx, "ok", nil).AsError())
+	})
+
+	t.Run("Failure returns APIError", func(t *testing.T) {
+		err := UnprocessableEntity(ctx, "balance too low").WithErrorCode("insufficient_balance").AsError()
+
+		var apiErr *APIError
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, 422, apiErr.StatusCode)
+		assert.Equal(t, "balance too low", apiErr.Message)
+		assert.Equal(t, "req-123", apiErr.RequestID)
+		assert.Equal(t, "insufficient_balance", apiErr.ErrorCode)
+		assert.Equal(t, "api error 422 (insufficient_balance): balance too low [request_id=req-123]", err.Error())
+	})
+
+	t.Run("errors.Is matches on status and code", func(t *testing.T) {
+		err := fmt.Errorf("get user: %w", NotFound(ctx, "user not found").WithErrorCode("user_not_found").AsError())
+
+		assert.True(t, errors.Is(err, &APIError{StatusCode: 404}))
+		assert.True(t, errors.Is(err, &APIError{ErrorCode: "user_not_found"}))
+		assert.True(t, errors.Is(err, &APIError{StatusCode: 404, ErrorCode: "user_not_found"}))
+		assert.False(t, errors.Is(err, &APIError{StatusCode: 400}))
+		assert.False(t, errors.Is(err, &APIError{StatusCode: 404, ErrorCode: "other"}))
+		assert.Equal(t, "api error 404 (user_not_found): user not found [request_id=req-123]", errors.Unwrap(err).Error())
+	})
+}
+
+func TestFromError(t *testing.T) {
+	ctx := activity.WithRequestID(context.Background(), "req-123")
+
+	t.Run("StatusError survives wrapping", func(t *testing.T) {
+		err := fmt.Errorf("handler: %w", fmt.Errorf("get user: %w", NewError(404, "user_not_found", "user not found")))
+		resp := FromError(ctx, err)
+
+		assert.False(t, resp.Meta.Success)
+		assert.Equal(t, 404, resp.Meta.StatusCode)
+		assert.Equal(t, "user_not_found", resp.Meta.ErrorCode)
+		assert.Equal(t, "user not found", resp.Meta.Message)
+		assert.Equal(t, "req-123", resp.Meta.RequestID)
+	})
+
+	t.Run("Empty message falls back to the default", func(t *testing.T) {
+		resp := FromError(ctx, NewError(409, "", ""))
+		assert.Equal(t, 409, resp.Meta.StatusCode)
+		assert.Equal(t, DefaultMessage(409), resp.Meta.Message)
+		assert.Empty(t, resp.Meta.ErrorCode)
+	})
+
+	t.Run("Non-error status becomes 500", func(t *testing.T) {
+		err := NewError(200, "oops", "not really an error")
+		assert.Equal(t, "status 500 (oops): not really an error", err.Error())
+		assert.Equal(t, 500, FromError(ctx, err).Meta.StatusCode)
+	})
+
+	t.Run("Other errors become a plain 500", func(t *testing.T) {
+		resp := FromError(ctx, errors.New("db: connection refused"))
+		assert.Equal(t, 500, resp.Meta.StatusCode)
+		assert.Equal(t, "internal server error", resp.Meta.Message)
+		assert.Nil(t, resp.Debug)
+
+		assert.Equal(t, 500, FromError(ctx, nil).Meta.StatusCode)
+	})
+
+	t.Run("Error string", func(t *testing.T) {
+		assert.Equal(t, "status 404 (user_not_found): user not found", NewError(404, "user_not_found", "user not found").Error())
+		assert.Equal(t, "status 400: bad input", NewError(400, "", "bad input").Error())
+	})
+}
@@ -0,0 +1,68 @@
+package response
+
+import (
+	"net/http"
+	"strings"
+)
+
+// HandleETag sets the ETag header and answers conditional requests.
+// If the request's If-None-Match matches etag (weak comparison, "*" matches
+// anything), it answers and returns true so the handler can return without
+// doing the work; otherwise it returns false and the handler continues as usual.
+//
+// As RFC 9110 §13.1.2 requires, only GET and HEAD get a bodyless 304 Not
+// Modified (with the ETag header). Any other method that matches gets a
+// 412 Precondition Failed envelope, e.g. a PUT with "If-None-Match: *"
+// guarding against overwriting an existing resource. The ETag header is set
+// for GET and HEAD only, since other methods change the resource.
+//
+// etag may be given with or without quotes; it is sent quoted.
+//
+// Example:
+//
+//	etag := fmt.Sprintf("%d-%d", user.ID, user.UpdatedAt.Unix())
+//	if response.HandleETag(w, r, etag) {
+//	    return
+//	}
+//	// write the 200 response
+func HandleETag(w http.ResponseWriter, r *http.Request, etag string) bool {
+	etag = quoteETag(etag)
+	safe := r.Method == http.MethodGet || r.Method == http.MethodHead
+	if safe {
+		w.Header().Set("ETag", etag)
+	}
+
+	if !etagMatches(r.Header.Get("If-None-Match"), etag) {
+		return false
+	}
+	if !safe {
+		Write(w, PreconditionFailed(r.Context(), ""))
+		return true
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// quoteETag wraps etag in double quotes unless it is already quoted or weak.
+func quoteETag(etag string) string {
+	if strings.HasPrefix(etag, `"`) || strings.HasPrefix(etag, `W/"`) {
+		return etag
+	}
+	return `"` + etag + `"`
+}
+
+// etagMatches reports whether an If-None-Match header matches etag using the
+// weak comparison required by RFC 9110 (the W/ prefix is ignored).
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	want := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == want {
+			return true
+		}
+	}
+	return false
+}
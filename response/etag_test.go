@@ -0,0 +1,88 @@
+package response
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotModified(t *testing.T) {
+	resp := NotModified(context.Background())
+	assert.True(t, resp.Meta.Success)
+	assert.Equal(t, 304, resp.Meta.StatusCode)
+	assert.Nil(t, resp.Data)
+}
+
+func TestHandleETag(t *testing.T) {
+	tests := []struct {
+		name        string
+		etag        string
+		ifNoneMatch string
+		matched     bool
+	}{
+		{"No header", "v1", "", false},
+		{"Exact match", "v1", `"v1"`, true},
+		{"Already quoted", `"v1"`, `"v1"`, true},
+		{"Weak match", "v1", `W/"v1"`, true},
+		{"List match", "v2", `"v1", "v2"`, true},
+		{"Wildcard", "v1", "*", true},
+		{"Mismatch", "v2", `"v1"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+
+			assert.Equal(t, tt.matched, HandleETag(rec, req, tt.etag))
+			assert.Equal(t, `"`+trimQuotes(tt.etag)+`"`, rec.Header().Get("ETag"))
+			if tt.matched {
+				assert.Equal(t, http.StatusNotModified, rec.Code)
+				assert.Empty(t, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleETag_UnsafeMethods(t *testing.T) {
+	for _, method := range []string{http.MethodPut, http.MethodPost, http.MethodDelete, http.MethodPatch} {
+		t.Run(method, func(t *testing.T) {
+			// Match: 412 envelope instead of 304
+			req := httptest.NewRequest(method, "/users/1", nil)
+			req.Header.Set("If-None-Match", "*")
+			rec := httptest.NewRecorder()
+
+			assert.True(t, HandleETag(rec, req, "v1"))
+			assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+			assert.Contains(t, rec.Body.String(), `"status_code":412`)
+			assert.Empty(t, rec.Header().Get("ETag"))
+
+			// No match: handler proceeds
+			req = httptest.NewRequest(method, "/users/1", nil)
+			req.Header.Set("If-None-Match", `"v0"`)
+			rec = httptest.NewRecorder()
+			assert.False(t, HandleETag(rec, req, "v1"))
+			assert.Equal(t, http.StatusOK, rec.Code)
+		})
+	}
+
+	// HEAD behaves like GET
+	req := httptest.NewRequest(http.MethodHead, "/users/1", nil)
+	req.Header.Set("If-None-Match", `"v1"`)
+	rec := httptest.NewRecorder()
+	assert.True(t, HandleETag(rec, req, "v1"))
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+}
+
+func trimQuotes(s string) string {
+	if len(s) >= 2 && s[0] == '"' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
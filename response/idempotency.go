@@ -0,0 +1,186 @@
+package response
+
+import (
+	"bytes"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// IdempotencyHeader is the request header carrying the client's idempotency key.
+const IdempotencyHeader = "Idempotency-Key"
+
+// IdempotencyRecord is a stored HTTP reply that can be replayed byte-for-byte.
+type IdempotencyRecord struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// IdempotencyStore persists replies keyed by idempotency key.
+// Implementations own expiry (e.g. Redis SET with EX); Get must not return expired records.
+// Methods are called concurrently.
+type IdempotencyStore interface {
+	Get(key string) (IdempotencyRecord, bool)
+	Set(key string, record IdempotencyRecord)
+}
+
+// Idempotent is middleware that replays the stored reply when a request repeats
+// an Idempotency-Key for the same method and path. Use it on payment/create POSTs
+// so client retries never execute the handler twice.
+//
+// Behavior:
+//   - Requests without the header pass straight through.
+//   - Only 2xx replies are stored; errors can be retried normally.
+//   - Replays carry the original status, headers, and body plus "Idempotent-Replayed: true".
+//   - A concurrent request with a key still in flight gets 409 Conflict.
+//
+// Example:
+//
+//	store := response.NewMemoryIdempotencyStore(24 * time.Hour)
+//	mux.Handle("POST /payments", response.Idempotent(store, paymentHandler))
+func Idempotent(store IdempotencyStore, next http.Handler) http.Handler {
+	var mu sync.Mutex
+	inFlight := make(map[string]struct{})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idemKey := r.Header.Get(IdempotencyHeader)
+		if idemKey == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// Scope key by method and path so keys cannot collide across endpoints
+		key := r.Method + " " + r.URL.Path + " " + idemKey
+
+		// Replay stored reply
+		if record, ok := store.Get(key); ok {
+			replay(w, record)
+			return
+		}
+
+		// Reject concurrent duplicates instead of executing twice
+		mu.Lock()
+		if _, busy := inFlight[key]; busy {
+			mu.Unlock()
+			Write(w, Conflict(r.Context(), "request with this idempotency key is in progress"))
+			return
+		}
+		inFlight[key] = struct{}{}
+		mu.Unlock()
+
+		defer func() {
+			mu.Lock()
+			delete(inFlight, key)
+			mu.Unlock()
+		}()
+
+		// Re-check: a request may have completed between Get and acquiring the slot
+		if record, ok := store.Get(key); ok {
+			replay(w, record)
+			return
+		}
+
+		rec := &recordingWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		// Cache successful replies only
+		if rec.status >= 200 && rec.status < 300 {
+			store.Set(key, IdempotencyRecord{
+				StatusCode: rec.status,
+				Header:     w.Header().Clone(),
+				Body:       rec.body.Bytes(),
+			})
+		}
+	})
+}
+
+// replay writes a stored record to w.
+func replay(w http.ResponseWriter, record IdempotencyRecord) {
+	for k, v := range record.Header {
+		w.Header()[k] = append([]string(nil), v...)
+	}
+	w.Header().Set("Idempotent-Replayed", "true")
+	w.WriteHeader(record.StatusCode)
+	_, _ = w.Write(record.Body)
+}
+
+// recordingWriter passes writes through while keeping a copy of status and body.
+type recordingWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rw *recordingWriter) WriteHeader(status int) {
+	if !rw.wroteHeader {
+		rw.status = status
+		rw.wroteHeader = true
+	}
+	rw.ResponseWriter.WriteHeader(status)
+}
+
+func (rw *recordingWriter) Write(b []byte) (int, error) {
+	rw.wroteHeader = true
+	rw.body.Write(b)
+	return rw.ResponseWriter.Write(b)
+}
+
+// =============================================================================
+// IN-MEMORY STORE
+// =============================================================================
+
+// MemoryIdempotencyStore is an in-process IdempotencyStore with a fixed TTL.
+// Suitable for single-instance services and tests; use a shared store
+// (Redis, DB) when running multiple replicas.
+type MemoryIdempotencyStore struct {
+	ttl       time.Duration
+	mu        sync.Mutex
+	records   map[string]memoryRecord
+	nextSweep time.Time
+}
+
+type memoryRecord struct {
+	record    IdempotencyRecord
+	expiresAt time.Time
+}
+
+// NewMemoryIdempotencyStore creates an in-memory store whose entries expire after ttl.
+func NewMemoryIdempotencyStore(ttl time.Duration) *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{ttl: ttl, records: make(map[string]memoryRecord)}
+}
+
+// Get returns a non-expired record. Expired entries are removed lazily.
+func (s *MemoryIdempotencyStore) Get(key string) (IdempotencyRecord, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.records[key]
+	if !ok {
+		return IdempotencyRecord{}, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(s.records, key)
+		return IdempotencyRecord{}, false
+	}
+	return entry.record, true
+}
+
+// Set stores a record for the configured TTL.
+func (s *MemoryIdempotencyStore) Set(key string, record IdempotencyRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	// Sweep at most once per TTL: bounds memory without a background goroutine
+	if now.After(s.nextSweep) {
+		for k, entry := range s.records {
+			if now.After(entry.expiresAt) {
+				delete(s.records, k)
+			}
+		}
+		s.nextSweep = now.Add(s.ttl)
+	}
+	s.records[key] = memoryRecord{record: record, expiresAt: now.Add(s.ttl)}
+}
@@ -0,0 +1,126 @@
+package response
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotent(t *testing.T) {
+	var calls int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		if r.URL.Query().Get("fail") == "1" {
+			Write(w, BadRequest(r.Context(), "invalid amount"))
+			return
+		}
+		Write(w, Created(r.Context(), "payment created", map[string]int32{"attempt": n}))
+	})
+
+	newRequest := func(path, key string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		if key != "" {
+			req.Header.Set(IdempotencyHeader, key)
+		}
+		return req
+	}
+
+	t.Run("Replays successful response", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		mw := Idempotent(NewMemoryIdempotencyStore(time.Minute), handler)
+
+		first := httptest.NewRecorder()
+		mw.ServeHTTP(first, newRequest("/payments", "key-1"))
+		second := httptest.NewRecorder()
+		mw.ServeHTTP(second, newRequest("/payments", "key-1"))
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+		assert.Equal(t, http.StatusCreated, second.Code)
+		assert.Equal(t, first.Body.String(), second.Body.String())
+		assert.Equal(t, "application/json", second.Header().Get("Content-Type"))
+		assert.Equal(t, "true", second.Header().Get("Idempotent-Replayed"))
+		assert.Empty(t, first.Header().Get("Idempotent-Replayed"))
+	})
+
+	t.Run("Key is scoped by path", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		mw := Idempotent(NewMemoryIdempotencyStore(time.Minute), handler)
+
+		mw.ServeHTTP(httptest.NewRecorder(), newRequest("/payments", "key-1"))
+		mw.ServeHTTP(httptest.NewRecorder(), newRequest("/refunds", "key-1"))
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Errors are not cached", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		mw := Idempotent(NewMemoryIdempotencyStore(time.Minute), handler)
+
+		for i := 0; i < 2; i++ {
+			rec := httptest.NewRecorder()
+			mw.ServeHTTP(rec, newRequest("/payments?fail=1", "key-err"))
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		}
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Requests without key pass through", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		mw := Idempotent(NewMemoryIdempotencyStore(time.Minute), handler)
+
+		mw.ServeHTTP(httptest.NewRecorder(), newRequest("/payments", ""))
+		mw.ServeHTTP(httptest.NewRecorder(), newRequest("/payments", ""))
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Concurrent duplicate is rejected", func(t *testing.T) {
+		release := make(chan struct{})
+		started := make(chan struct{})
+		slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-release
+			Write(w, Created(r.Context(), "payment created", nil))
+		})
+		mw := Idempotent(NewMemoryIdempotencyStore(time.Minute), slow)
+
+		done := make(chan struct{})
+		go func() {
+			mw.ServeHTTP(httptest.NewRecorder(), newRequest("/payments", "key-busy"))
+			close(done)
+		}()
+		<-started
+
+		rec := httptest.NewRecorder()
+		mw.ServeHTTP(rec, newRequest("/payments", "key-busy"))
+		assert.Equal(t, http.StatusConflict, rec.Code)
+
+		close(release)
+		<-done
+	})
+}
+
+func TestMemoryIdempotencyStore_TTL(t *testing.T) {
+	store := NewMemoryIdempotencyStore(20 * time.Millisecond)
+	store.Set("k", IdempotencyRecord{StatusCode: 200, Body: []byte("ok")})
+
+	rec, ok := store.Get("k")
+	assert.True(t, ok)
+	assert.Equal(t, "ok", string(rec.Body))
+
+	time.Sleep(30 * time.Millisecond)
+	_, ok = store.Get("k")
+	assert.False(t, ok)
+
+	// Sweep removes expired entries on later writes
+	for i := 0; i < 3; i++ {
+		store.Set(fmt.Sprintf("old-%d", i), IdempotencyRecord{StatusCode: 200})
+	}
+	time.Sleep(30 * time.Millisecond)
+	store.Set("fresh", IdempotencyRecord{StatusCode: 200})
+	assert.Len(t, store.records, 1)
+}
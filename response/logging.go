@@ -0,0 +1,132 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Jkenyut/nvx-go-helper/activity"
+)
+
+// maxLoggedBody caps how much of a response body LogBodies keeps in memory.
+const maxLoggedBody = 64 << 10
+
+// LogBodies is middleware that logs every response with its status, duration,
+// request_id, and body (the written Response envelope) at Info level.
+//
+// Behavior:
+//   - Bodies of requests whose path is in skipPaths (exact match, e.g. "/login")
+//     are never captured; the line is still logged without a body.
+//   - Streaming responses (text/event-stream) pass through untouched and are
+//     logged without a body; Flush is forwarded, so StreamSSE keeps working.
+//   - Bodies above 64 KiB are logged truncated; JSON bodies are logged as
+//     nested JSON, anything else as a string.
+//   - request_id comes from the context, else the RequestIDHeader set by Write.
+//
+// Example:
+//
+//	logged := response.LogBodies(slog.Default(), []string{"/login", "/register"})
+//	http.ListenAndServe(":8080", logged(mux))
+func LogBodies(logger *slog.Logger, skipPaths []string) func(http.Handler) http.Handler {
+	skip := make(map[string]struct{}, len(skipPaths))
+	for _, p := range skipPaths {
+		skip[p] = struct{}{}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, skipBody := skip[r.URL.Path]
+			lw := &loggingWriter{ResponseWriter: w, status: http.StatusOK, capture: !skipBody}
+
+			start := time.Now()
+			next.ServeHTTP(lw, r)
+
+			requestID, _ := activity.GetRequestID(r.Context())
+			if requestID == "" && RequestIDHeader != "" {
+				requestID = w.Header().Get(RequestIDHeader)
+			}
+
+			attrs := []slog.Attr{
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.Int("status", lw.status),
+				slog.Int64("duration_ms", time.Since(start).Milliseconds()),
+				slog.String("request_id", requestID),
+			}
+			if lw.capture && lw.body.Len() > 0 {
+				attrs = append(attrs, bodyAttr(lw.body.Bytes(), lw.truncated))
+			}
+			logger.LogAttrs(r.Context(), slog.LevelInfo, "http response", attrs...)
+		})
+	}
+}
+
+// bodyAttr renders a captured body: nested JSON when it is a complete JSON
+// value, otherwise a string.
+func bodyAttr(body []byte, truncated bool) slog.Attr {
+	if !truncated && json.Valid(body) {
+		return slog.Any("body", json.RawMessage(bytes.TrimSpace(body)))
+	}
+	s := string(body)
+	if truncated {
+		s += "...(truncated)"
+	}
+	return slog.String("body", s)
+}
+
+// loggingWriter passes writes through while keeping the status and, when
+// capture is set, up to maxLoggedBody bytes of the body.
+type loggingWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	capture     bool
+	truncated   bool
+	body        bytes.Buffer
+}
+
+func (lw *loggingWriter) WriteHeader(status int) {
+	if !lw.wroteHeader {
+		lw.status = status
+		lw.wroteHeader = true
+		// Never buffer streams: they are long-lived and unbounded
+		if strings.HasPrefix(lw.Header().Get("Content-Type"), "text/event-stream") {
+			lw.capture = false
+		}
+	}
+	lw.ResponseWriter.WriteHeader(status)
+}
+
+func (lw *loggingWriter) Write(b []byte) (int, error) {
+	if !lw.wroteHeader {
+		lw.WriteHeader(http.StatusOK)
+	}
+	if lw.capture {
+		if room := maxLoggedBody - lw.body.Len(); room < len(b) {
+			lw.body.Write(b[:max(room, 0)])
+			lw.truncated = true
+		} else {
+			lw.body.Write(b)
+		}
+	}
+	return lw.ResponseWriter.Write(b)
+}
+
+// Flush forwards to the underlying writer so streaming handlers keep working.
+func (lw *loggingWriter) Flush() {
+	// Flushing commits the headers; record it so Write does not send them again
+	if !lw.wroteHeader {
+		lw.WriteHeader(http.StatusOK)
+	}
+	if f, ok := lw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (lw *loggingWriter) Unwrap() http.ResponseWriter {
+	return lw.ResponseWriter
+}
@@ -0,0 +1,85 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Jkenyut/nvx-go-helper/activity"
+	"github.com/stretchr/testify/assert"
+)
+
+// logEntry runs handler behind LogBodies and returns the single decoded log line.
+func logEntry(t *testing.T, skipPaths []string, path string, handler http.HandlerFunc) (map[string]any, *httptest.ResponseRecorder) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	rec := httptest.NewRecorder()
+	LogBodies(logger, skipPaths)(handler).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, nil))
+
+	var entry map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	return entry, rec
+}
+
+func TestLogBodies(t *testing.T) {
+	t.Run("Logs the response envelope", func(t *testing.T) {
+		entry, rec := logEntry(t, nil, "/users", func(w http.ResponseWriter, r *http.Request) {
+			Write(w, Created(activity.WithRequestID(r.Context(), "req-log"), "user created", map[string]int{"id": 7}))
+		})
+
+		assert.Equal(t, 201, rec.Code)
+		assert.Equal(t, "http response", entry["msg"])
+		assert.Equal(t, "POST", entry["method"])
+		assert.Equal(t, "/users", entry["path"])
+		assert.EqualValues(t, 201, entry["status"])
+		assert.Equal(t, "req-log", entry["request_id"]) // from the X-Request-ID header
+
+		body, ok := entry["body"].(map[string]any)
+		if assert.True(t, ok, "body should be nested JSON") {
+			assert.Equal(t, map[string]any{"id": float64(7)}, body["data"])
+		}
+	})
+
+	t.Run("Skip paths never log the body", func(t *testing.T) {
+		entry, rec := logEntry(t, []string{"/login"}, "/login", func(w http.ResponseWriter, r *http.Request) {
+			Write(w, OK(activity.WithRequestID(r.Context(), "req-login"), "ok", map[string]string{"token": "secret"}))
+		})
+
+		assert.Contains(t, rec.Body.String(), "secret") // client still gets it
+		assert.NotContains(t, entry, "body")
+		assert.Equal(t, "req-login", entry["request_id"])
+		assert.EqualValues(t, 200, entry["status"])
+	})
+
+	t.Run("Streaming responses pass through", func(t *testing.T) {
+		entry, rec := logEntry(t, nil, "/events", func(w http.ResponseWriter, r *http.Request) {
+			events := make(chan Response, 1)
+			events <- OK(r.Context(), "tick", nil)
+			close(events)
+			assert.NoError(t, StreamSSE(r.Context(), w, events))
+		})
+
+		assert.True(t, rec.Flushed)
+		assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Body.String(), "data: ")
+		assert.NotContains(t, entry, "body")
+	})
+
+	t.Run("Large bodies are truncated", func(t *testing.T) {
+		entry, rec := logEntry(t, nil, "/export", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(strings.Repeat("x", maxLoggedBody+100)))
+		})
+
+		assert.Equal(t, maxLoggedBody+100, rec.Body.Len())
+		body, _ := entry["body"].(string)
+		assert.True(t, strings.HasSuffix(body, "...(truncated)"))
+		assert.Len(t, body, maxLoggedBody+len("...(truncated)"))
+	})
+}
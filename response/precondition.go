@@ -0,0 +1,51 @@
+package response
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// RequirePayloadLimit checks the request body size against maxBytes.
+// Returns a 413 PayloadTooLarge response and false when the declared
+// Content-Length exceeds the limit, otherwise a zero Response and true.
+//
+// Bodies without a declared length (chunked uploads) cannot be checked up front,
+// so r.Body is also capped: reading past maxBytes fails with *http.MaxBytesError.
+//
+// Example:
+//
+//	if resp, ok := response.RequirePayloadLimit(r, 1<<20); !ok {
+//	    response.Write(w, resp)
+//	    return
+//	}
+func RequirePayloadLimit(r *http.Request, maxBytes int64) (Response, bool) {
+	if r.ContentLength > maxBytes {
+		return PayloadTooLarge(r.Context(), fmt.Sprintf("request body exceeds %d bytes", maxBytes)), false
+	}
+	if r.Body != nil {
+		r.Body = http.MaxBytesReader(nil, r.Body, maxBytes)
+	}
+	return Response{}, true
+}
+
+// RequireContentType checks that the request Content-Type matches contentType.
+// Parameters such as charset are ignored and the comparison is case-insensitive.
+// Returns a 415 UnsupportedMediaType response and false on mismatch or missing
+// header, otherwise a zero Response and true.
+//
+// Example:
+//
+//	if resp, ok := response.RequireContentType(r, "application/json"); !ok {
+//	    response.Write(w, resp)
+//	    return
+//	}
+func RequireContentType(r *http.Request, contentType string) (Response, bool) {
+	// Strip parameters (e.g. "; charset=utf-8")
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || !strings.EqualFold(mediaType, contentType) {
+		return UnsupportedMediaType(r.Context(), fmt.Sprintf("content type must be %s", contentType)), false
+	}
+	return Response{}, true
+}
@@ -0,0 +1,69 @@
+package response
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequirePayloadLimit(t *testing.T) {
+	t.Run("Within limit", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("hello"))
+		resp, ok := RequirePayloadLimit(req, 10)
+		assert.True(t, ok)
+		assert.Equal(t, Response{}, resp)
+	})
+
+	t.Run("Declared length exceeds limit", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("hello world"))
+		resp, ok := RequirePayloadLimit(req, 5)
+		assert.False(t, ok)
+		assert.Equal(t, 413, resp.Meta.StatusCode)
+		assert.Equal(t, "request body exceeds 5 bytes", resp.Meta.Message)
+	})
+
+	t.Run("Unknown length is capped on read", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("hello world"))
+		req.ContentLength = -1
+		_, ok := RequirePayloadLimit(req, 5)
+		assert.True(t, ok)
+
+		_, err := io.ReadAll(req.Body)
+		var maxErr *http.MaxBytesError
+		assert.True(t, errors.As(err, &maxErr))
+	})
+}
+
+func TestRequireContentType(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		ok          bool
+	}{
+		{"Exact", "application/json", true},
+		{"With charset", "application/json; charset=utf-8", true},
+		{"Case insensitive", "Application/JSON", true},
+		{"Mismatch", "text/plain", false},
+		{"Missing", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			resp, ok := RequireContentType(req, "application/json")
+			assert.Equal(t, tt.ok, ok)
+			if !tt.ok {
+				assert.Equal(t, 415, resp.Meta.StatusCode)
+				assert.Equal(t, "content type must be application/json", resp.Meta.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package response
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/Jkenyut/nvx-go-helper/pagination"
+)
+
+// ErrInvalidPagination is wrapped by ParsePagination for malformed query params.
+var ErrInvalidPagination = errors.New("invalid pagination")
+
+// ParsePagination reads the "page" and "per_page" query params of r.
+// Missing or zero values fall back to page 1 and defaultPerPage; per_page is
+// clamped to maxPerPage. offset is the SQL OFFSET for the page.
+// Non-numeric or negative values return an error wrapping ErrInvalidPagination,
+// suitable as a 400 message.
+//
+// A defaultPerPage below 1 uses pagination.DefaultLimit, and a maxPerPage below 1
+// uses pagination.MaxLimit.
+//
+// Example:
+//
+//	page, perPage, offset, err := response.ParsePagination(r, 20, 100)
+//	if err != nil {
+//	    response.Write(w, response.BadRequest(r.Context(), err.Error()))
+//	    return
+//	}
+//	rows, _ := db.Limit(perPage).Offset(offset).Find(&users)
+func ParsePagination(r *http.Request, defaultPerPage, maxPerPage int) (page, perPage, offset int, err error) {
+	if maxPerPage < 1 {
+		maxPerPage = pagination.MaxLimit
+	}
+	if defaultPerPage < 1 {
+		defaultPerPage = pagination.DefaultLimit
+	}
+
+	query := r.URL.Query()
+	if page, err = queryInt(query.Get("page"), "page"); err != nil {
+		return 0, 0, 0, err
+	}
+	if perPage, err = queryInt(query.Get("per_page"), "per_page"); err != nil {
+		return 0, 0, 0, err
+	}
+
+	// Apply defaults and bounds
+	if page == 0 {
+		page = pagination.DefaultPage
+	}
+	if perPage == 0 {
+		perPage = defaultPerPage
+	}
+	if perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+
+	// Guard (page-1)*perPage against overflow
+	if page-1 > math.MaxInt/perPage {
+		return 0, 0, 0, fmt.Errorf("%w: page %d is out of range", ErrInvalidPagination, page)
+	}
+	return page, perPage, (page - 1) * perPage, nil
+}
+
+// queryInt parses an optional non-negative integer query value; empty is 0.
+func queryInt(value, name string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%w: %s must be a non-negative integer", ErrInvalidPagination, name)
+	}
+	return n, nil
+}
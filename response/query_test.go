@@ -0,0 +1,53 @@
+package response
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePagination(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		page       int
+		perPage    int
+		offset     int
+		wantErrMsg string
+	}{
+		{"defaults", "", 1, 20, 0, ""},
+		{"explicit", "?page=3&per_page=25", 3, 25, 50, ""},
+		{"zero falls back to defaults", "?page=0&per_page=0", 1, 20, 0, ""},
+		{"per_page clamped", "?page=2&per_page=500", 2, 100, 100, ""},
+		{"negative page", "?page=-1", 0, 0, 0, "invalid pagination: page must be a non-negative integer"},
+		{"negative per_page", "?per_page=-5", 0, 0, 0, "invalid pagination: per_page must be a non-negative integer"},
+		{"non-numeric", "?page=abc", 0, 0, 0, "invalid pagination: page must be a non-negative integer"},
+		{"offset overflow", "?page=9223372036854775807&per_page=100", 0, 0, 0, "invalid pagination: page 9223372036854775807 is out of range"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/users"+tt.query, nil)
+			page, perPage, offset, err := ParsePagination(r, 20, 100)
+
+			if tt.wantErrMsg != "" {
+				assert.ErrorIs(t, err, ErrInvalidPagination)
+				assert.EqualError(t, err, tt.wantErrMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.page, page)
+			assert.Equal(t, tt.perPage, perPage)
+			assert.Equal(t, tt.offset, offset)
+		})
+	}
+
+	t.Run("invalid bounds use package defaults", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/users", nil)
+		_, perPage, _, err := ParsePagination(r, 0, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 10, perPage)
+	})
+}
@@ -0,0 +1,195 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// RedactedValue replaces sensitive values in redacted output.
+const RedactedValue = "***"
+
+// JSONMarshalRedacted serializes the response for audit logging with the values of
+// sensitiveKeys (matched case-insensitively against JSON field names, at any depth)
+// replaced by "***". Works for maps, structs (via their json tags), and slices.
+//
+// The original Data is never mutated: the payload is round-tripped through JSON
+// and the copy is redacted. If Data cannot be serialized it is omitted entirely,
+// so a failure never leaks unredacted content.
+//
+// Example:
+//
+//	log.Println(string(resp.JSONMarshalRedacted([]string{"password", "token"})))
+func (r Response) JSONMarshalRedacted(sensitiveKeys []string) []byte {
+	// Build a lookup set of lowercase keys
+	keys := make(map[string]struct{}, len(sensitiveKeys))
+	for _, k := range sensitiveKeys {
+		keys[strings.ToLower(k)] = struct{}{}
+	}
+
+	out := Response{Meta: r.Meta}
+	if r.Data != nil {
+		if generic, ok := toGeneric(r.Data); ok {
+			out.Data = redactKeys(generic, keys)
+		}
+	}
+
+	// Meta and generic JSON values always marshal successfully
+	b, _ := json.Marshal(out)
+	return b
+}
+
+// toGeneric converts any JSON-serializable value into maps/slices/primitives.
+// Numbers are kept as json.Number to avoid float64 precision loss.
+func toGeneric(v any) (any, bool) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, false
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+
+	var generic any
+	if err := dec.Decode(&generic); err != nil {
+		return nil, false
+	}
+	return generic, true
+}
+
+// redactKeys walks a generic JSON value and masks values of matching keys in place.
+// It is only called on a freshly decoded copy.
+func redactKeys(v any, keys map[string]struct{}) any {
+	switch value := v.(type) {
+	case map[string]any:
+		for k, child := range value {
+			if _, sensitive := keys[strings.ToLower(k)]; sensitive {
+				value[k] = RedactedValue
+				continue
+			}
+			value[k] = redactKeys(child, keys)
+		}
+		return value
+	case []any:
+		for i, child := range value {
+			value[i] = redactKeys(child, keys)
+		}
+		return value
+	default:
+		return value
+	}
+}
+
+// RedactByTag returns a deep copy of data with every exported struct field tagged
+// `sensitive:"true"` masked: strings become "***" and other types their zero value.
+// Nested structs, pointers, slices, arrays, maps, and interface values are walked;
+// the result has the same type as data and data itself is never mutated.
+// Unexported fields are copied as is. Pointer cycles are preserved, not followed forever.
+//
+// Example:
+//
+//	type Login struct {
+//	    Email    string `json:"email"`
+//	    Password string `json:"password" sensitive:"true"`
+//	}
+//	resp.Data = response.RedactByTag(resp.Data) // Password: "***"
+func RedactByTag(data any) any {
+	if data == nil {
+		return nil
+	}
+	copied := redactValue(reflect.ValueOf(data), map[seenKey]reflect.Value{})
+	return copied.Interface()
+}
+
+// seenKey identifies a copied pointer. The type is part of the key because a
+// pointer to a struct and a pointer to its first field share an address.
+type seenKey struct {
+	typ  reflect.Type
+	addr uintptr
+}
+
+// redactValue returns a redacted deep copy of v. seen maps already copied
+// pointers to their copies, so shared and cyclic pointers stay consistent.
+func redactValue(v reflect.Value, seen map[seenKey]reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		key := seenKey{v.Type(), v.Pointer()}
+		if copied, ok := seen[key]; ok {
+			return copied
+		}
+		copied := reflect.New(v.Type().Elem())
+		seen[key] = copied
+		copied.Elem().Set(redactValue(v.Elem(), seen))
+		return copied
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(redactValue(v.Elem(), seen))
+		return copied
+
+	case reflect.Struct:
+		// Start from a shallow copy so unexported fields are kept
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if field.Tag.Get("sensitive") == "true" {
+				copied.Field(i).Set(maskedValue(field.Type))
+				continue
+			}
+			copied.Field(i).Set(redactValue(v.Field(i), seen))
+		}
+		return copied
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(redactValue(v.Index(i), seen))
+		}
+		return copied
+
+	case reflect.Array:
+		copied := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(redactValue(v.Index(i), seen))
+		}
+		return copied
+
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), redactValue(iter.Value(), seen))
+		}
+		return copied
+
+	default:
+		// Scalars, funcs, and channels are copied by value
+		return v
+	}
+}
+
+// maskedValue returns the masked replacement for a sensitive field of type t:
+// RedactedValue for string kinds, the zero value otherwise.
+func maskedValue(t reflect.Type) reflect.Value {
+	masked := reflect.New(t).Elem()
+	if t.Kind() == reflect.String {
+		masked.SetString(RedactedValue)
+	}
+	return masked
+}
@@ -0,0 +1,157 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Jkenyut/nvx-go-helper/activity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONMarshalRedacted(t *testing.T) {
+	ctx := activity.WithRequestID(context.Background(), "req-redact")
+
+	t.Run("Map data", func(t *testing.T) {
+		data := map[string]any{
+			"username": "budi",
+			"password": "s3cret",
+			"profile":  map[string]any{"Token": "abc", "city": "Jakarta"},
+		}
+		resp := OK(ctx, "ok", data)
+
+		jsonStr := string(resp.JSONMarshalRedacted([]string{"password", "token"}))
+
+		assert.Contains(t, jsonStr, `"password":"***"`)
+		assert.Contains(t, jsonStr, `"Token":"***"`)
+		assert.Contains(t, jsonStr, `"username":"budi"`)
+		assert.Contains(t, jsonStr, `"city":"Jakarta"`)
+		assert.Contains(t, jsonStr, `"request_id":"req-redact"`)
+		assert.NotContains(t, jsonStr, "s3cret")
+
+		// Original data is untouched
+		assert.Equal(t, "s3cret", data["password"])
+		assert.Equal(t, "abc", data["profile"].(map[string]any)["Token"])
+	})
+
+	t.Run("Struct and slice data", func(t *testing.T) {
+		type card struct {
+			Number string `json:"card_number"`
+			Bank   string `json:"bank"`
+		}
+		type user struct {
+			ID    int64  `json:"id"`
+			Cards []card `json:"cards"`
+		}
+		data := user{ID: 9007199254740993, Cards: []card{{Number: "4111", Bank: "BRI"}}}
+		resp := OK(ctx, "ok", data)
+
+		jsonStr := string(resp.JSONMarshalRedacted([]string{"card_number"}))
+
+		assert.Contains(t, jsonStr, `"card_number":"***"`)
+		assert.Contains(t, jsonStr, `"bank":"BRI"`)
+		assert.Contains(t, jsonStr, `"id":9007199254740993`) // no float precision loss
+		assert.Equal(t, "4111", data.Cards[0].Number)
+	})
+
+	t.Run("Unserializable data is omitted", func(t *testing.T) {
+		resp := OK(ctx, "ok", map[string]any{"fn": func() {}})
+		jsonStr := string(resp.JSONMarshalRedacted(nil))
+
+		assert.Contains(t, jsonStr, `"success":true`)
+		assert.NotContains(t, jsonStr, `"data"`)
+	})
+}
+
+type redactCard struct {
+	Last4  string `json:"last4"`
+	Number string `json:"number" sensitive:"true"`
+	CVV    int    `json:"cvv" sensitive:"true"`
+}
+
+type redactUser struct {
+	Email    string            `json:"email"`
+	Password string            `json:"password" sensitive:"true"`
+	Token    *string           `json:"token" sensitive:"true"`
+	Card     redactCard        `json:"card"`
+	Cards    []redactCard      `json:"cards"`
+	ByName   map[string]any    `json:"by_name"`
+	Backup   *redactCard       `json:"backup"`
+	Labels   map[string]string `json:"labels"`
+	Next     *redactUser       `json:"-"`
+	internal string
+}
+
+func TestRedactByTag(t *testing.T) {
+	token := "secret-token"
+	user := &redactUser{
+		Email:    "budi@example.com",
+		Password: "hunter2",
+		Token:    &token,
+		Card:     redactCard{Last4: "4242", Number: "4242424242424242", CVV: 123},
+		Cards:    []redactCard{{Last4: "1111", Number: "4111111111111111", CVV: 456}},
+		ByName:   map[string]any{"primary": redactCard{Last4: "0005", Number: "378282246310005"}},
+		Backup:   &redactCard{Last4: "5100", Number: "5105105105105100", CVV: 789},
+		Labels:   map[string]string{"tier": "gold"},
+		internal: "kept",
+	}
+	user.Next = user // cycle
+
+	redacted, ok := RedactByTag(user).(*redactUser)
+	if !assert.True(t, ok) {
+		return
+	}
+
+	assert.Equal(t, "budi@example.com", redacted.Email)
+	assert.Equal(t, RedactedValue, redacted.Password)
+	assert.Nil(t, redacted.Token)
+	assert.Equal(t, redactCard{Last4: "4242", Number: RedactedValue}, redacted.Card)
+	assert.Equal(t, []redactCard{{Last4: "1111", Number: RedactedValue}}, redacted.Cards)
+	assert.Equal(t, redactCard{Last4: "0005", Number: RedactedValue}, redacted.ByName["primary"])
+	assert.Equal(t, &redactCard{Last4: "5100", Number: RedactedValue}, redacted.Backup)
+	assert.Equal(t, "gold", redacted.Labels["tier"])
+	assert.Equal(t, "kept", redacted.internal)
+	assert.Same(t, redacted, redacted.Next) // cycle preserved in the copy
+
+	// Original untouched
+	assert.Equal(t, "hunter2", user.Password)
+	assert.Equal(t, "4242424242424242", user.Card.Number)
+	assert.Equal(t, "4111111111111111", user.Cards[0].Number)
+	assert.Equal(t, 789, user.Backup.CVV)
+	assert.Equal(t, "secret-token", *user.Token)
+
+	// Non-pointer values and nil
+	card := RedactByTag(redactCard{Last4: "4242", Number: "4242424242424242"})
+	assert.Equal(t, redactCard{Last4: "4242", Number: RedactedValue}, card)
+	assert.Nil(t, RedactByTag(nil))
+	assert.Equal(t, 42, RedactByTag(42))
+}
+
+type redactOwner struct {
+	Card redactCard `json:"card"`
+}
+
+func TestRedactByTag_FieldPointerAlias(t *testing.T) {
+	// &owner and &owner.Card share an address but differ in type
+	owner := &redactOwner{Card: redactCard{Last4: "4242", Number: "4242424242424242"}}
+	holders := []any{
+		&struct {
+			Owner *redactOwner
+			Card  *redactCard
+		}{owner, &owner.Card},
+		&struct {
+			Card  *redactCard
+			Owner *redactOwner
+		}{&owner.Card, owner},
+	}
+
+	for _, h := range holders {
+		var redacted any
+		assert.NotPanics(t, func() { redacted = RedactByTag(h) })
+		b, _ := json.Marshal(redacted)
+		assert.NotContains(t, string(b), "4242424242424242")
+		assert.Equal(t, 2, strings.Count(string(b), `"number":"***"`))
+	}
+	assert.Equal(t, "4242424242424242", owner.Card.Number)
+}
@@ -25,6 +25,11 @@ package response
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/Jkenyut/nvx-go-helper/activity"
 	"github.com/Jkenyut/nvx-go-helper/cryptoutil"
@@ -33,36 +38,104 @@ import (
 // Meta holds the metadata for the API response.
 // It contains status information, messages, and tracing IDs.
 type Meta struct {
-	Success    bool   `json:"success"`     // true for 2xx, false for 4xx/5xx
-	Message    string `json:"message"`     // human-readable, lowercase
-	StatusCode int    `json:"status_code"` // HTTP status code as int
-	RequestID  string `json:"request_id"`  // correlation ID for tracing
+	Success    bool     `json:"success"`               // true for 2xx, false for 4xx/5xx
+	Message    string   `json:"message"`               // human-readable, lowercase
+	StatusCode int      `json:"status_code"`           // HTTP status code as int
+	RequestID  string   `json:"request_id"`            // correlation ID for tracing
+	ErrorCode  string   `json:"error_code,omitempty"`  // machine-readable code, e.g. "insufficient_balance"
+	DurationMS *int64   `json:"duration_ms,omitempty"` // handler time, nil when ctx has no start time (0 is still sent)
+	Warnings   []string `json:"warnings,omitempty"`    // non-fatal issues on a successful request
+	Location   string   `json:"location,omitempty"`    // URL of a created resource, sent as the Location header by Write
 }
 
 // Response is the standard top-level JSON structure.
 // All API endpoints must return this structure.
 type Response struct {
-	Meta Meta `json:"meta"`           // always present
-	Data any  `json:"data,omitempty"` // omitted when nil
+	Meta  Meta   `json:"meta"`            // always present
+	Data  any    `json:"data,omitempty"`  // omitted when nil
+	Debug *Debug `json:"debug,omitempty"` // only set when SetIncludeDebug is enabled
+}
+
+// IDGenerator produces request IDs when none is present in the context.
+// Defaults to cryptoutil.V4. Override it once at startup (e.g. ULID/nanoid),
+// or in tests for deterministic output; it is not guarded for concurrent writes.
+//
+// Example:
+//
+//	response.IDGenerator = func() string { return "fixed-id" }
+var IDGenerator func() string = cryptoutil.V4
+
+// RequestIDHeader is the response header Write and SetRequestIDHeader use to echo
+// the request ID. Defaults to "X-Request-ID"; set it to "" to disable the header.
+// Like IDGenerator, set it once at startup.
+//
+// Example:
+//
+//	response.RequestIDHeader = "X-Correlation-ID"
+var RequestIDHeader = "X-Request-ID"
+
+// lowercaseMessages controls whether NewMeta lowercases messages. Off by default.
+var lowercaseMessages atomic.Bool
+
+// SetLowercaseMessages enables or disables lowercasing of every message passed to NewMeta,
+// enforcing the lowercase convention in one place instead of at each call site.
+// Leave it disabled for partner APIs that expect sentence case. Safe for concurrent use.
+//
+// Example:
+//
+//	response.SetLowercaseMessages(true)
+//	response.Created(ctx, "User Created", nil) // message: "user created"
+func SetLowercaseMessages(enabled bool) {
+	lowercaseMessages.Store(enabled)
+}
+
+// LowercaseMessages reports whether NewMeta lowercases messages.
+func LowercaseMessages() bool {
+	return lowercaseMessages.Load()
 }
 
 // NewMeta builds metadata with correct request_id precedence:
 // 1. From context (middleware/header)
-// 2. Generate new UUID v4
+// 2. Generate new ID via IDGenerator (UUID v4 by default)
+//
+// If ctx carries a start time (activity.WithStartTime), DurationMS points to the
+// milliseconds elapsed since then, so a sub-millisecond handler reports 0;
+// otherwise it is nil and duration_ms is omitted.
+//
+// An empty message falls back to DefaultMessage(status), so every constructor
+// accepts "" for the obvious case: NotFound(ctx, "") → "not found".
 func NewMeta(ctx context.Context, success bool, message string, status int) Meta {
 	// Try to get request ID from context
 	reqID, _ := activity.GetRequestID(ctx)
-	// If not found, generate a new random UUID v4
+	// If not found, generate a new one
 	if reqID == "" {
-		reqID = cryptoutil.V4()
+		reqID = IDGenerator()
+	}
+
+	// Fall back to the status phrase when no message is given
+	if message == "" {
+		message = DefaultMessage(status)
+	}
+
+	// Normalize message casing if enabled
+	if lowercaseMessages.Load() {
+		message = strings.ToLower(message)
+	}
+
+	// Report handler latency if the start time was recorded
+	var durationMS *int64
+	if start, ok := activity.GetStartTime(ctx); ok {
+		elapsed := time.Since(start).Milliseconds()
+		durationMS = &elapsed
 	}
 
 	// Return the constructed Meta struct
 	return Meta{
-		Success:    success, // Success status
-		Message:    message, // Message string
-		StatusCode: status,  // HTTP status code
-		RequestID:  reqID,   // Tracing ID
+		Success:    success,    // Success status
+		Message:    message,    // Message string
+		StatusCode: status,     // HTTP status code
+		RequestID:  reqID,      // Tracing ID
+		DurationMS: durationMS, // Elapsed time in ms
 	}
 }
 
@@ -78,16 +151,49 @@ func Created(ctx context.Context, message string, data any) Response {
 	return Response{Meta: NewMeta(ctx, true, message, 201), Data: data}
 }
 
+// CreatedAt sends a 201 Created response with data and the URL of the new resource.
+// The URL is stored in meta.location; Write also emits it as the Location header.
+//
+// Example:
+//
+//	response.Write(w, response.CreatedAt(ctx, "user created", user, "/users/"+user.ID))
+func CreatedAt(ctx context.Context, message string, data any, location string) Response {
+	meta := NewMeta(ctx, true, message, 201)
+	meta.Location = location
+	return Response{Meta: meta, Data: data}
+}
+
 // Accepted sends a 202 Accepted response with data.
 func Accepted(ctx context.Context, message string, data any) Response {
 	return Response{Meta: NewMeta(ctx, true, message, 202), Data: data}
 }
 
+// OKWithWarnings sends a 200 OK response with data and non-fatal warnings.
+// Success stays true; clients can surface the warnings (e.g. "sms delivery failed").
+// Warnings are omitted from JSON when the slice is empty.
+//
+// Example:
+//
+//	return response.OKWithWarnings(ctx, "order placed", order, []string{"sms notification failed"})
+func OKWithWarnings(ctx context.Context, message string, data any, warnings []string) Response {
+	meta := NewMeta(ctx, true, message, 200)
+	if len(warnings) > 0 {
+		meta.Warnings = warnings
+	}
+	return Response{Meta: meta, Data: data}
+}
+
 // NoContent sends a 204 No Content response.
 func NoContent(ctx context.Context) Response {
 	return Response{Meta: NewMeta(ctx, true, "no content", 204)}
 }
 
+// NotModified sends a 304 Not Modified response.
+// The HTTP reply must not carry a body; see HandleETag.
+func NotModified(ctx context.Context) Response {
+	return Response{Meta: NewMeta(ctx, true, "not modified", 304)}
+}
+
 // === ERROR RESPONSES (4xx & 5xx) ===
 
 // BadRequest sends a 400 Bad Request response.
@@ -205,3 +311,123 @@ func WithMessageData(ctx context.Context, message string, status int, data any)
 	success := status >= 200 && status < 300
 	return Response{Meta: NewMeta(ctx, success, message, status), Data: data}
 }
+
+// WithErrorCode returns a copy of the response with a machine-readable error code,
+// letting clients branch on the code instead of parsing the message.
+//
+// Example:
+//
+//	return response.UnprocessableEntity(ctx, "balance too low").WithErrorCode("insufficient_balance")
+func (r Response) WithErrorCode(code string) Response {
+	r.Meta.ErrorCode = code
+	return r
+}
+
+// SetData replaces Data and returns r for chaining.
+//
+// Example:
+//
+//	resp := response.OK(ctx, "success", nil)
+//	resp.SetData(user).MergeData(map[string]any{"permissions": perms})
+func (r *Response) SetData(data any) *Response {
+	r.Data = data
+	return r
+}
+
+// MergeData overlays fields onto Data and returns r for chaining; fields win on conflict.
+//   - nil Data becomes a new map holding fields.
+//   - map[string]any Data is copied before merging, so the caller's map is not mutated.
+//   - Other objects (structs, typed maps) are converted via their JSON form
+//     into a map[string]any first, so json tags decide the keys.
+//   - Data that is not a JSON object (slices, primitives) is left unchanged.
+//
+// Example:
+//
+//	resp := response.OK(ctx, "success", user)
+//	if includeOrders {
+//	    resp.MergeData(map[string]any{"orders": orders})
+//	}
+func (r *Response) MergeData(fields map[string]any) *Response {
+	var merged map[string]any
+	switch data := r.Data.(type) {
+	case nil:
+		merged = make(map[string]any, len(fields))
+	case map[string]any:
+		merged = make(map[string]any, len(data)+len(fields))
+		for k, v := range data {
+			merged[k] = v
+		}
+	default:
+		generic, ok := toGeneric(data)
+		obj, isObject := generic.(map[string]any)
+		if !ok || !isObject {
+			return r
+		}
+		merged = obj
+	}
+
+	for k, v := range fields {
+		merged[k] = v
+	}
+	r.Data = merged
+	return r
+}
+
+// Write sends resp as JSON with Meta.StatusCode as the HTTP status.
+// It sets Content-Type, the RequestIDHeader from Meta.RequestID, and the
+// Location header when Meta.Location is set.
+// 204 and 304 responses are sent without a body, as HTTP requires.
+// A status outside 100-999 (e.g. a zero-value Response) would make
+// net/http panic, so it is replaced by 200 when Meta.Success is true and
+// 500 otherwise, in both the header and meta.status_code.
+//
+// Example:
+//
+//	response.Write(w, response.OK(r.Context(), "success", user))
+func Write(w http.ResponseWriter, resp Response) {
+	if resp.Meta.StatusCode < 100 || resp.Meta.StatusCode > 999 {
+		resp.Meta.StatusCode = http.StatusInternalServerError
+		if resp.Meta.Success {
+			resp.Meta.StatusCode = http.StatusOK
+		}
+	}
+
+	if RequestIDHeader != "" && resp.Meta.RequestID != "" {
+		w.Header().Set(RequestIDHeader, resp.Meta.RequestID)
+	}
+	if resp.Meta.Location != "" {
+		w.Header().Set("Location", resp.Meta.Location)
+	}
+
+	status := resp.Meta.StatusCode
+	if status == http.StatusNoContent || status == http.StatusNotModified {
+		w.WriteHeader(status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// SetRequestIDHeader sets RequestIDHeader on w for handlers that do not use Write.
+// The ID is taken from r's context (set by middleware), falling back to the same
+// header on the incoming request. Does nothing if neither is present.
+//
+// Example:
+//
+//	response.SetRequestIDHeader(w, r)
+//	http.ServeContent(w, r, name, modTime, file)
+func SetRequestIDHeader(w http.ResponseWriter, r *http.Request) {
+	if RequestIDHeader == "" {
+		return
+	}
+
+	reqID, ok := activity.GetRequestID(r.Context())
+	if !ok || reqID == "" {
+		reqID = r.Header.Get(RequestIDHeader)
+	}
+	if reqID != "" {
+		w.Header().Set(RequestIDHeader, reqID)
+	}
+}
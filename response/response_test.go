@@ -3,7 +3,10 @@ package response
 import (
 	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/Jkenyut/nvx-go-helper/activity"
 	"github.com/google/uuid"
@@ -33,6 +36,72 @@ func TestNewMeta_GeneratesNewIDWhenMissing(t *testing.T) {
 	assert.Len(t, meta1.RequestID, 36)                   // UUID format
 }
 
+func TestNewMeta_CustomIDGenerator(t *testing.T) {
+	original := IDGenerator
+	defer func() { IDGenerator = original }()
+
+	IDGenerator = func() string { return "custom-id" }
+
+	meta := NewMeta(context.Background(), true, "test", 200)
+	assert.Equal(t, "custom-id", meta.RequestID)
+
+	// Context value still takes precedence
+	ctx := activity.WithRequestID(context.Background(), "ctx-id")
+	assert.Equal(t, "ctx-id", NewMeta(ctx, true, "test", 200).RequestID)
+}
+
+func TestNewMeta_LowercaseMessages(t *testing.T) {
+	defer SetLowercaseMessages(false)
+
+	// Default preserves caller casing
+	assert.False(t, LowercaseMessages())
+	assert.Equal(t, "User created successfully", NewMeta(context.Background(), true, "User created successfully", 201).Message)
+
+	SetLowercaseMessages(true)
+	assert.True(t, LowercaseMessages())
+	assert.Equal(t, "user created successfully", Created(context.Background(), "User Created Successfully", nil).Meta.Message)
+}
+
+func TestNewMeta_Duration(t *testing.T) {
+	// Absent start time omits the field
+	meta := NewMeta(context.Background(), true, "ok", 200)
+	assert.Nil(t, meta.DurationMS)
+	b, _ := json.Marshal(meta)
+	assert.NotContains(t, string(b), "duration_ms")
+
+	ctx := activity.WithStartTime(context.Background(), time.Now().Add(-150*time.Millisecond))
+	meta = NewMeta(ctx, true, "ok", 200)
+	if assert.NotNil(t, meta.DurationMS) {
+		assert.GreaterOrEqual(t, *meta.DurationMS, int64(150))
+	}
+	b, _ = json.Marshal(meta)
+	assert.Contains(t, string(b), `"duration_ms":`)
+
+	// A sub-millisecond handler still reports 0
+	meta = NewMeta(activity.WithStartTime(context.Background(), time.Now()), true, "ok", 200)
+	if assert.NotNil(t, meta.DurationMS) {
+		assert.Zero(t, *meta.DurationMS)
+	}
+	b, _ = json.Marshal(meta)
+	assert.Contains(t, string(b), `"duration_ms":0`)
+}
+
+func TestOKWithWarnings(t *testing.T) {
+	ctx := activity.WithRequestID(context.Background(), "req-warn")
+
+	resp := OKWithWarnings(ctx, "order placed", "data", []string{"sms notification failed"})
+	assert.True(t, resp.Meta.Success)
+	assert.Equal(t, 200, resp.Meta.StatusCode)
+	assert.Equal(t, []string{"sms notification failed"}, resp.Meta.Warnings)
+
+	b, _ := json.Marshal(resp)
+	assert.Contains(t, string(b), `"warnings":["sms notification failed"]`)
+
+	// Empty (non-nil) slice is omitted
+	b, _ = json.Marshal(OKWithWarnings(ctx, "order placed", nil, []string{}))
+	assert.NotContains(t, string(b), "warnings")
+}
+
 func TestSuccessResponses(t *testing.T) {
 	ctx := activity.WithRequestID(context.Background(), "fixed-id-123")
 
@@ -129,3 +198,151 @@ func TestResponse_WithMessage(t *testing.T) {
 	assert.Contains(t, jsonStrErr, `"success":false`)
 	assert.Contains(t, jsonStrErr, `"status_code":400`)
 }
+
+func TestCreatedAt(t *testing.T) {
+	resp := CreatedAt(context.Background(), "user created", map[string]string{"id": "42"}, "/users/42")
+	assert.Equal(t, 201, resp.Meta.StatusCode)
+	assert.True(t, resp.Meta.Success)
+	assert.Equal(t, "/users/42", resp.Meta.Location)
+
+	b, _ := json.Marshal(resp)
+	assert.Contains(t, string(b), `"location":"/users/42"`)
+
+	// Omitted when unset
+	b, _ = json.Marshal(Created(context.Background(), "user created", nil))
+	assert.NotContains(t, string(b), "location")
+}
+
+func TestWrite(t *testing.T) {
+	ctx := activity.WithRequestID(context.Background(), "req-write")
+
+	t.Run("JSON body and status", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		Write(rec, NotFound(ctx, "user not found"))
+
+		assert.Equal(t, 404, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		assert.Empty(t, rec.Header().Get("Location"))
+
+		var decoded Response
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &decoded))
+		assert.Equal(t, "user not found", decoded.Meta.Message)
+		assert.Equal(t, "req-write", decoded.Meta.RequestID)
+		assert.Equal(t, "req-write", rec.Header().Get("X-Request-ID"))
+	})
+
+	t.Run("Custom request ID header", func(t *testing.T) {
+		defer func() { RequestIDHeader = "X-Request-ID" }()
+
+		RequestIDHeader = "X-Correlation-ID"
+		rec := httptest.NewRecorder()
+		Write(rec, OK(ctx, "ok", nil))
+		assert.Equal(t, "req-write", rec.Header().Get("X-Correlation-ID"))
+		assert.Empty(t, rec.Header().Get("X-Request-ID"))
+
+		RequestIDHeader = ""
+		rec = httptest.NewRecorder()
+		Write(rec, OK(ctx, "ok", nil))
+		assert.Empty(t, rec.Header().Get("X-Correlation-ID"))
+	})
+
+	t.Run("Location header", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		Write(rec, CreatedAt(ctx, "user created", nil, "/users/42"))
+
+		assert.Equal(t, 201, rec.Code)
+		assert.Equal(t, "/users/42", rec.Header().Get("Location"))
+	})
+
+	t.Run("No body for 204 and 304", func(t *testing.T) {
+		for _, resp := range []Response{NoContent(ctx), NotModified(ctx)} {
+			rec := httptest.NewRecorder()
+			Write(rec, resp)
+			assert.Equal(t, resp.Meta.StatusCode, rec.Code)
+			assert.Empty(t, rec.Body.String())
+		}
+	})
+
+	t.Run("Invalid status falls back instead of panicking", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		assert.NotPanics(t, func() { Write(rec, Response{}) })
+		assert.Equal(t, 500, rec.Code)
+
+		var decoded Response
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &decoded))
+		assert.Equal(t, 500, decoded.Meta.StatusCode)
+
+		rec = httptest.NewRecorder()
+		Write(rec, Response{Meta: Meta{Success: true, StatusCode: 1000}, Data: "ok"})
+		assert.Equal(t, 200, rec.Code)
+	})
+}
+
+func TestSetRequestIDHeader(t *testing.T) {
+	t.Run("From context", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r = r.WithContext(activity.WithRequestID(r.Context(), "req-ctx"))
+		r.Header.Set("X-Request-ID", "req-header")
+		rec := httptest.NewRecorder()
+
+		SetRequestIDHeader(rec, r)
+		assert.Equal(t, "req-ctx", rec.Header().Get("X-Request-ID"))
+	})
+
+	t.Run("Falls back to request header", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("X-Request-ID", "req-header")
+		rec := httptest.NewRecorder()
+
+		SetRequestIDHeader(rec, r)
+		assert.Equal(t, "req-header", rec.Header().Get("X-Request-ID"))
+	})
+
+	t.Run("Nothing to set", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		SetRequestIDHeader(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Empty(t, rec.Header().Get("X-Request-ID"))
+	})
+}
+
+func TestSetDataMergeData(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("SetData", func(t *testing.T) {
+		resp := OK(ctx, "success", nil)
+		assert.Equal(t, "user", resp.SetData("user").Data)
+	})
+
+	t.Run("Nil data is initialized", func(t *testing.T) {
+		resp := OK(ctx, "success", nil)
+		resp.MergeData(map[string]any{"a": 1}).MergeData(map[string]any{"b": 2})
+		assert.Equal(t, map[string]any{"a": 1, "b": 2}, resp.Data)
+	})
+
+	t.Run("Map data is copied and overlaid", func(t *testing.T) {
+		original := map[string]any{"id": 1, "name": "budi"}
+		resp := OK(ctx, "success", original)
+		resp.MergeData(map[string]any{"name": "siti", "role": "admin"})
+
+		assert.Equal(t, map[string]any{"id": 1, "name": "siti", "role": "admin"}, resp.Data)
+		assert.Equal(t, "budi", original["name"]) // not mutated
+	})
+
+	t.Run("Struct data uses json tags", func(t *testing.T) {
+		type user struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		}
+		resp := OK(ctx, "success", user{ID: 7, Name: "budi"})
+		resp.MergeData(map[string]any{"orders": 3})
+
+		b, _ := json.Marshal(resp.Data)
+		assert.JSONEq(t, `{"id":7,"name":"budi","orders":3}`, string(b))
+	})
+
+	t.Run("Non-object data is unchanged", func(t *testing.T) {
+		resp := OK(ctx, "success", []int{1, 2})
+		resp.MergeData(map[string]any{"a": 1})
+		assert.Equal(t, []int{1, 2}, resp.Data)
+	})
+}
@@ -0,0 +1,46 @@
+package response
+
+import (
+	"context"
+
+	"github.com/Jkenyut/nvx-go-helper/pagination"
+	"github.com/Jkenyut/nvx-go-helper/worker"
+)
+
+// ResultsPage is the Data of FromResults: one page of processed items.
+type ResultsPage[R any] struct {
+	Items      []R                   `json:"items"`            // successful values, in result order; never null
+	Errors     []ItemError           `json:"errors,omitempty"` // failed or skipped jobs
+	Pagination pagination.Pagination `json:"pagination"`
+}
+
+// ItemError reports one failed job of a batch.
+type ItemError struct {
+	ID    int    `json:"id"`    // worker job ID
+	Error string `json:"error"` // err.Error(); make sure it is safe to show clients
+}
+
+// FromResults builds a 200 OK response carrying a page of worker results:
+// successful values go to data.items, failures to data.errors (with their job
+// IDs), and p to data.pagination. A page with failures is still a 200; clients
+// check data.errors for partial success.
+//
+// Example:
+//
+//	results, _ := worker.Drain(ctx, worker.RunGenericWorkerPoolStream(ctx, jobs, enrich, nil, cfg))
+//	p := pagination.New(r.URL.Query().Get("page"), r.URL.Query().Get("limit"), total)
+//	response.Write(w, response.FromResults(ctx, results, p))
+func FromResults[R any](ctx context.Context, results []worker.Result[R], p pagination.Pagination) Response {
+	page := ResultsPage[R]{
+		Items:      make([]R, 0, len(results)),
+		Pagination: p,
+	}
+	for _, res := range results {
+		if res.Err != nil {
+			page.Errors = append(page.Errors, ItemError{ID: res.ID, Error: res.Err.Error()})
+			continue
+		}
+		page.Items = append(page.Items, res.Value)
+	}
+	return OK(ctx, "", page)
+}
@@ -0,0 +1,54 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Jkenyut/nvx-go-helper/pagination"
+	"github.com/Jkenyut/nvx-go-helper/worker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromResults(t *testing.T) {
+	results := []worker.Result[string]{
+		{ID: 1, Value: "a"},
+		{ID: 2, Err: errors.New("not found")},
+		{ID: 3, Value: "c"},
+		{ID: 4, Err: worker.ErrSkipped},
+	}
+	p := pagination.New("1", "4", 10)
+
+	resp := FromResults(context.Background(), results, p)
+
+	assert.Equal(t, 200, resp.Meta.StatusCode)
+	assert.True(t, resp.Meta.Success)
+
+	page, ok := resp.Data.(ResultsPage[string])
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.Equal(t, []string{"a", "c"}, page.Items)
+	assert.Equal(t, []ItemError{
+		{ID: 2, Error: "not found"},
+		{ID: 4, Error: worker.ErrSkipped.Error()},
+	}, page.Errors)
+	assert.Equal(t, p, page.Pagination)
+}
+
+func TestFromResults_JSONShape(t *testing.T) {
+	// No results: items is [] (not null) and errors is omitted
+	resp := FromResults[int](context.Background(), nil, pagination.New("", "", 0))
+
+	b, err := json.Marshal(resp)
+	assert.NoError(t, err)
+
+	var decoded struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, "[]", string(decoded.Data["items"]))
+	assert.NotContains(t, decoded.Data, "errors")
+	assert.Contains(t, decoded.Data, "pagination")
+}
@@ -0,0 +1,52 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// SafeID is an int64 ID that marshals to JSON as a string.
+// JavaScript numbers are IEEE 754 doubles and silently round integers above
+// 2^53 (9007199254740992), so Snowflake-style IDs in Data get corrupted on the
+// frontend when sent as numbers. Use SafeID for such fields instead of int64.
+//
+// Unmarshal accepts both "123" and 123, so older clients keep working.
+//
+// Example:
+//
+//	type OrderDTO struct {
+//	    ID   response.SafeID `json:"id"` // "1790123456789012345"
+//	    Name string          `json:"name"`
+//	}
+//	return response.OK(ctx, "success", OrderDTO{ID: response.SafeID(order.ID)})
+type SafeID int64
+
+// MarshalJSON encodes the ID as a quoted decimal string.
+func (id SafeID) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + strconv.FormatInt(int64(id), 10) + `"`), nil
+}
+
+// UnmarshalJSON decodes the ID from a quoted string or a bare number.
+// JSON null leaves the ID unchanged.
+func (id *SafeID) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+	s := string(b)
+	if len(b) >= 2 && b[0] == '"' && b[len(b)-1] == '"' {
+		s = string(b[1 : len(b)-1])
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("response: invalid SafeID %s: %w", b, err)
+	}
+	*id = SafeID(n)
+	return nil
+}
+
+// Compile-time interface checks
+var (
+	_ json.Marshaler   = SafeID(0)
+	_ json.Unmarshaler = (*SafeID)(nil)
+)
@@ -0,0 +1,34 @@
+package response
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSafeID(t *testing.T) {
+	type dto struct {
+		ID SafeID `json:"id"`
+	}
+
+	t.Run("Marshals as string", func(t *testing.T) {
+		b, err := json.Marshal(dto{ID: 1790123456789012345})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"id":"1790123456789012345"}`, string(b))
+	})
+
+	t.Run("Unmarshals string and number", func(t *testing.T) {
+		var fromString, fromNumber dto
+		assert.NoError(t, json.Unmarshal([]byte(`{"id":"1790123456789012345"}`), &fromString))
+		assert.NoError(t, json.Unmarshal([]byte(`{"id":42}`), &fromNumber))
+		assert.Equal(t, SafeID(1790123456789012345), fromString.ID)
+		assert.Equal(t, SafeID(42), fromNumber.ID)
+	})
+
+	t.Run("Rejects invalid input", func(t *testing.T) {
+		var d dto
+		assert.Error(t, json.Unmarshal([]byte(`{"id":"abc"}`), &d))
+		assert.Error(t, json.Unmarshal([]byte(`{"id":1.5}`), &d))
+	})
+}
@@ -0,0 +1,87 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// SSEHeartbeat is how often StreamSSE writes a comment line to keep idle
+// connections open through proxies and load balancers. A value <= 0 disables
+// heartbeats. Set it once at startup; each stream reads it when it starts.
+var SSEHeartbeat = 15 * time.Second
+
+// ErrStreamingUnsupported is returned by StreamSSE when w cannot be flushed.
+var ErrStreamingUnsupported = errors.New("response: streaming unsupported by ResponseWriter")
+
+// StreamSSE streams events to the client as Server-Sent Events, one
+// "data: <json>" message per Response, flushed immediately.
+// A ": heartbeat" comment is written every SSEHeartbeat while idle, unless
+// SSEHeartbeat is <= 0.
+//
+// Returns nil when events is closed, ctx.Err() when ctx is done (e.g. the client
+// disconnected), or the write/encode error. Call it as the last step of the handler.
+//
+// Example:
+//
+//	func dashboard(w http.ResponseWriter, r *http.Request) {
+//	    events := make(chan response.Response)
+//	    go publishUpdates(r.Context(), events) // closes events when done
+//	    _ = response.StreamSSE(r.Context(), w, events)
+//	}
+func StreamSSE(ctx context.Context, w http.ResponseWriter, events <-chan Response) error {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return ErrStreamingUnsupported
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // disable nginx buffering
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	// A nil channel never fires, so disabled heartbeats need no special case below
+	interval := SSEHeartbeat
+	var heartbeat *time.Ticker
+	var heartbeatC <-chan time.Time
+	if interval > 0 {
+		heartbeat = time.NewTicker(interval)
+		defer heartbeat.Stop()
+		heartbeatC = heartbeat.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-heartbeatC:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return err
+			}
+			flusher.Flush()
+
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				return err
+			}
+			// Marshalled JSON never contains raw newlines, so one data line is enough
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+				return err
+			}
+			flusher.Flush()
+			// Reset so the heartbeat only fires on idle streams
+			if heartbeat != nil {
+				heartbeat.Reset(interval)
+			}
+		}
+	}
+}
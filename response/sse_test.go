@@ -0,0 +1,85 @@
+package response
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Jkenyut/nvx-go-helper/activity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamSSE(t *testing.T) {
+	ctx := activity.WithRequestID(context.Background(), "req-sse")
+
+	t.Run("Writes events until channel closes", func(t *testing.T) {
+		events := make(chan Response, 2)
+		events <- OK(ctx, "tick", map[string]int{"n": 1})
+		events <- OK(ctx, "tick", map[string]int{"n": 2})
+		close(events)
+
+		rec := httptest.NewRecorder()
+		err := StreamSSE(ctx, rec, events)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "no-cache", rec.Header().Get("Cache-Control"))
+		assert.True(t, rec.Flushed)
+
+		body := rec.Body.String()
+		assert.Equal(t, 2, strings.Count(body, "data: "))
+		assert.Contains(t, body, `data: {"meta":{"success":true,"message":"tick","status_code":200,"request_id":"req-sse"},"data":{"n":1}}`+"\n\n")
+	})
+
+	t.Run("Stops on context cancel with heartbeat", func(t *testing.T) {
+		original := SSEHeartbeat
+		SSEHeartbeat = 10 * time.Millisecond
+		defer func() { SSEHeartbeat = original }()
+
+		cctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		rec := httptest.NewRecorder()
+		err := StreamSSE(cctx, rec, make(chan Response))
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, rec.Body.String(), ": heartbeat\n\n")
+	})
+
+	t.Run("Zero heartbeat disables it", func(t *testing.T) {
+		original := SSEHeartbeat
+		SSEHeartbeat = 0
+		defer func() { SSEHeartbeat = original }()
+
+		cctx, cancel := context.WithTimeout(ctx, 30*time.Millisecond)
+		defer cancel()
+
+		// One event exercises the post-write reset path as well
+		events := make(chan Response, 1)
+		events <- OK(ctx, "tick", nil)
+
+		rec := httptest.NewRecorder()
+		var err error
+		assert.NotPanics(t, func() { err = StreamSSE(cctx, rec, events) })
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, rec.Body.String(), "data: ")
+		assert.NotContains(t, rec.Body.String(), "heartbeat")
+	})
+
+	t.Run("Requires a Flusher", func(t *testing.T) {
+		err := StreamSSE(ctx, nonFlusher{httptest.NewRecorder()}, make(chan Response))
+		assert.ErrorIs(t, err, ErrStreamingUnsupported)
+	})
+}
+
+// nonFlusher hides the recorder's Flush method
+type nonFlusher struct {
+	w *httptest.ResponseRecorder
+}
+
+func (n nonFlusher) Header() http.Header         { return n.w.Header() }
+func (n nonFlusher) Write(b []byte) (int, error) { return n.w.Write(b) }
+func (n nonFlusher) WriteHeader(status int)      { n.w.WriteHeader(status) }
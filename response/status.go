@@ -0,0 +1,133 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"unicode/utf16"
+)
+
+// includeStatusText controls whether Meta is serialized with status_text. Off by default.
+var includeStatusText atomic.Bool
+
+// SetIncludeStatusText enables or disables the "status_text" field in serialized Meta,
+// e.g. "status_code": 404, "status_text": "Not Found". Useful while debugging;
+// clients should keep relying on status_code. Safe for concurrent use.
+//
+// Example:
+//
+//	response.SetIncludeStatusText(true)
+func SetIncludeStatusText(enabled bool) {
+	includeStatusText.Store(enabled)
+}
+
+// IncludeStatusText reports whether Meta is serialized with status_text.
+func IncludeStatusText() bool {
+	return includeStatusText.Load()
+}
+
+// nonStandardStatusText covers widely used codes that net/http does not name.
+var nonStandardStatusText = map[int]string{
+	420: "Enhance Your Calm",     // Twitter rate limiting
+	499: "Client Closed Request", // nginx: client disconnected before the response
+	520: "Unknown Error",         // Cloudflare: origin returned an unexpected response
+	521: "Web Server Is Down",    // Cloudflare: origin refused the connection
+	522: "Connection Timed Out",  // Cloudflare: origin did not answer in time
+}
+
+// StatusText returns the reason phrase for m.StatusCode, e.g. "Not Found".
+// Codes unknown to net/http fall back to a few common non-standard phrases
+// (499 "Client Closed Request", 420 "Enhance Your Calm"), then to the class name
+// ("Client Error", "Server Error", ...), then to "Unknown Status".
+//
+// Example:
+//
+//	resp := response.NotFound(ctx, "user not found")
+//	resp.Meta.StatusText() // "Not Found"
+func (m Meta) StatusText() string {
+	if text := http.StatusText(m.StatusCode); text != "" {
+		return text
+	}
+	if text, ok := nonStandardStatusText[m.StatusCode]; ok {
+		return text
+	}
+
+	// Fall back to the status class
+	switch m.StatusCode / 100 {
+	case 1:
+		return "Informational"
+	case 2:
+		return "Success"
+	case 3:
+		return "Redirection"
+	case 4:
+		return "Client Error"
+	case 5:
+		return "Server Error"
+	}
+	return "Unknown Status"
+}
+
+// DefaultMessage returns the message used when a constructor is given "":
+// the lowercased status text, e.g. 404 → "not found", 403 → "forbidden".
+// Non-standard codes follow Meta.StatusText (499 → "client closed request").
+//
+// Example:
+//
+//	response.NotFound(ctx, "") // message: "not found"
+func DefaultMessage(status int) string {
+	return strings.ToLower(Meta{StatusCode: status}.StatusText())
+}
+
+// MarshalJSON serializes Meta as its tagged fields, adding status_text when
+// SetIncludeStatusText is enabled.
+func (m Meta) MarshalJSON() ([]byte, error) {
+	// plain has Meta's fields but not this method, avoiding recursion
+	type plain Meta
+	if !includeStatusText.Load() {
+		return json.Marshal(plain(m))
+	}
+	return json.Marshal(struct {
+		plain
+		StatusText string `json:"status_text"`
+	}{plain(m), m.StatusText()})
+}
+
+// JSON serializes m on its own, exactly as it appears under "meta" in a Response
+// (including status_text when enabled), for transports that carry meta in a
+// header and data in the body. No fields are defaulted. Non-ASCII characters
+// are escaped as \uXXXX, so the result is a valid single-line header value.
+//
+// Example:
+//
+//	w.Header().Set("X-Meta", string(resp.Meta.JSON()))
+//	_ = json.NewEncoder(w).Encode(resp.Data)
+func (m Meta) JSON() []byte {
+	// Meta holds only strings, numbers, and string slices: marshaling cannot fail
+	b, _ := json.Marshal(m)
+
+	var out []byte
+	for i, r := range string(b) {
+		if r < 0x80 {
+			if out != nil {
+				out = append(out, byte(r))
+			}
+			continue
+		}
+		if out == nil {
+			out = append(make([]byte, 0, len(b)+16), b[:i]...)
+		}
+		// Non-ASCII only occurs inside JSON strings, where \u escapes are valid
+		if r1, r2 := utf16.EncodeRune(r); r1 != '\uFFFD' {
+			out = fmt.Appendf(out, `\u%04x\u%04x`, r1, r2)
+		} else {
+			out = fmt.Appendf(out, `\u%04x`, r)
+		}
+	}
+	if out == nil {
+		return b
+	}
+	return out
+}
@@ -0,0 +1,102 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeta_StatusText(t *testing.T) {
+	tests := []struct {
+		code     int
+		expected string
+	}{
+		{200, "OK"},
+		{404, "Not Found"},
+		{503, "Service Unavailable"},
+		{499, "Client Closed Request"},
+		{420, "Enhance Your Calm"},
+		{299, "Success"},
+		{460, "Client Error"},
+		{599, "Server Error"},
+		{0, "Unknown Status"},
+		{999, "Unknown Status"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, Meta{StatusCode: tt.code}.StatusText(), "code %d", tt.code)
+	}
+}
+
+func TestDefaultMessage(t *testing.T) {
+	ctx := context.Background()
+
+	assert.Equal(t, "not found", DefaultMessage(404))
+	assert.Equal(t, "client closed request", DefaultMessage(499))
+
+	// Empty message falls back per status
+	assert.Equal(t, "not found", NotFound(ctx, "").Meta.Message)
+	assert.Equal(t, "forbidden", Forbidden(ctx, "").Meta.Message)
+	assert.Equal(t, "too many requests", TooManyRequests(ctx, "").Meta.Message)
+	assert.Equal(t, "ok", OK(ctx, "", nil).Meta.Message)
+	assert.Equal(t, "i'm a teapot", WithMessage(ctx, "", 418).Meta.Message)
+
+	// Explicit messages are unchanged
+	assert.Equal(t, "User Not Found", NotFound(ctx, "User Not Found").Meta.Message)
+}
+
+func TestMeta_MarshalStatusText(t *testing.T) {
+	resp := NotFound(context.Background(), "user not found")
+
+	// Off by default
+	b, err := json.Marshal(resp)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(b), "status_text")
+	assert.Contains(t, string(b), `"status_code":404`)
+
+	SetIncludeStatusText(true)
+	defer SetIncludeStatusText(false)
+	assert.True(t, IncludeStatusText())
+
+	b, err = json.Marshal(resp)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"status_text":"Not Found"`)
+
+	// Still decodes into Meta
+	var decoded Response
+	assert.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, resp.Meta, decoded.Meta)
+}
+
+func TestMeta_JSON(t *testing.T) {
+	meta := NotFound(context.Background(), "user not found").WithErrorCode("user_not_found").Meta
+
+	// Same bytes as the meta block inside a full response
+	var full map[string]json.RawMessage
+	b, err := json.Marshal(Response{Meta: meta})
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(b, &full))
+	assert.Equal(t, string(full["meta"]), string(meta.JSON()))
+
+	// No defaults applied to a zero Meta
+	assert.Equal(t, `{"success":false,"message":"","status_code":0,"request_id":""}`, string(Meta{}.JSON()))
+
+	// Header-safe: ASCII only, one line, and still decodes to the same text
+	meta.Message = "pesanan tidak ditemukan 🍛 – café\nbaris"
+	out := meta.JSON()
+	for _, c := range out {
+		assert.Less(t, c, byte(0x80))
+		assert.NotEqual(t, byte('\n'), c)
+	}
+	assert.Contains(t, string(out), `\ud83c\udf5b`)
+	var decoded Meta
+	assert.NoError(t, json.Unmarshal(out, &decoded))
+	assert.Equal(t, meta, decoded)
+
+	// Follows SetIncludeStatusText like MarshalJSON
+	SetIncludeStatusText(true)
+	defer SetIncludeStatusText(false)
+	assert.Contains(t, string(meta.JSON()), `"status_text":"Not Found"`)
+}
@@ -0,0 +1,58 @@
+package worker
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// ExponentialBackoff returns a function computing the delay before retry attempt n
+// (0-based): base * factor^n, capped at max.
+//
+// With jitter enabled it applies "full jitter": a uniform random delay in [0, d].
+// This spreads out retries from many clients and avoids thundering herds.
+// Jitter uses math/rand, which is fine because retry timing is not security-sensitive.
+//
+// Edge cases: factor < 1 is treated as 1 (constant backoff), max <= 0 disables
+// the cap, and a negative attempt is treated as 0.
+// The returned function is safe for concurrent use.
+//
+// Example:
+//
+//	backoff := worker.ExponentialBackoff(100*time.Millisecond, 5*time.Second, 2, true)
+//	time.Sleep(backoff(attempt))
+func ExponentialBackoff(base, max time.Duration, factor float64, jitter bool) func(attempt int) time.Duration {
+	if factor < 1 {
+		factor = 1
+	}
+
+	return func(attempt int) time.Duration {
+		if base <= 0 {
+			return 0
+		}
+		if attempt < 0 {
+			attempt = 0
+		}
+
+		limit := time.Duration(math.MaxInt64)
+		if max > 0 {
+			limit = max
+		}
+
+		// Compute in float64 and compare before converting back to avoid overflow
+		delay := limit
+		if d := float64(base) * math.Pow(factor, float64(attempt)); d < float64(limit) {
+			delay = time.Duration(d)
+		}
+
+		if jitter {
+			// Full jitter: uniform in [0, delay] (upper bound guarded against overflow)
+			n := int64(delay)
+			if n < math.MaxInt64 {
+				n++
+			}
+			delay = time.Duration(rand.Int64N(n))
+		}
+		return delay
+	}
+}
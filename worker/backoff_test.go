@@ -0,0 +1,73 @@
+package worker
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestExponentialBackoff verifies growth, capping, and edge cases without jitter
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(100*time.Millisecond, time.Second, 2, false)
+
+	expected := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second, // capped
+		time.Second,
+	}
+	for attempt, want := range expected {
+		if got := backoff(attempt); got != want {
+			t.Errorf("Attempt %d: expected %v, got %v", attempt, want, got)
+		}
+	}
+
+	if got := backoff(-1); got != 100*time.Millisecond {
+		t.Errorf("Negative attempt: expected base delay, got %v", got)
+	}
+
+	// Huge attempt must not overflow
+	if got := backoff(10000); got != time.Second {
+		t.Errorf("Huge attempt: expected cap, got %v", got)
+	}
+
+	// No cap and no overflow
+	uncapped := ExponentialBackoff(time.Second, 0, 10, false)
+	if got := uncapped(1000); got <= 0 {
+		t.Errorf("Uncapped huge attempt overflowed: %v", got)
+	}
+
+	// factor < 1 behaves as constant backoff
+	constant := ExponentialBackoff(50*time.Millisecond, time.Second, 0.5, false)
+	if got := constant(5); got != 50*time.Millisecond {
+		t.Errorf("Constant backoff: expected 50ms, got %v", got)
+	}
+}
+
+// TestExponentialBackoffJitter verifies jittered delays stay within bounds concurrently
+func TestExponentialBackoffJitter(t *testing.T) {
+	backoff := ExponentialBackoff(100*time.Millisecond, time.Second, 2, true)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for attempt := 0; attempt < 100; attempt++ {
+				d := backoff(attempt % 6)
+				if d < 0 || d > time.Second {
+					t.Errorf("Jittered delay out of range: %v", d)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Uncapped jitter at the overflow boundary must not panic
+	uncapped := ExponentialBackoff(time.Second, 0, 10, true)
+	if d := uncapped(1000); d < 0 {
+		t.Errorf("Uncapped jittered delay negative: %v", d)
+	}
+}
@@ -0,0 +1,134 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// RunGenericWorkerPoolChan is RunGenericWorkerPoolStream for unbounded input:
+// it reads jobs from in until in is closed and streams one Result per job.
+// Use it for continuous sources (Kafka consumer, queue tailing) where the jobs
+// are not known up front.
+//
+// Differences from the batch API:
+//   - GlobalTimeout is applied only when set explicitly; otherwise the pool
+//     runs until in is closed or ctx is cancelled.
+//   - Duplicate IDs cannot reject a batch that is still arriving: a job whose ID
+//     was already seen is not run and gets its own error Result instead.
+//     The seen-ID set grows by one entry per job for the life of the stream,
+//     so for endless streams set AutoReindex.
+//   - AutoReindex numbers jobs by arrival order (0, 1, 2, ...). Such IDs cannot
+//     repeat, so no seen-ID set is kept and memory stays flat.
+//   - The result buffer is ResultBuffer, else NumWorkers; keep draining results.
+//   - After cancellation, jobs still arriving on in get ErrSkipped until in is
+//     closed, so the producer never blocks. The producer must close in.
+//
+// Example:
+//
+//	in := make(chan worker.Job[Message])
+//	go func() {
+//	    defer close(in)
+//	    for id := 0; ; id++ {
+//	        msg, err := consumer.Read(ctx)
+//	        if err != nil {
+//	            return
+//	        }
+//	        in <- worker.Job[Message]{ID: id, Data: msg}
+//	    }
+//	}()
+//	for res := range worker.RunGenericWorkerPoolChan(ctx, in, handle, nil, cfg) { ... }
+func RunGenericWorkerPoolChan[T any, R any](
+	ctx context.Context,
+	in <-chan Job[T],
+	workerFunc func(context.Context, T) (R, error),
+	globalSemaphore chan struct{},
+	cfg WorkerPoolConfig,
+) <-chan Result[R] {
+	// Only bound the stream lifetime if the caller asked for it
+	bounded := cfg.GlobalTimeout > 0
+	cfg = withDefaults(cfg)
+
+	var poolCtx context.Context
+	var cancelPool context.CancelFunc
+	if bounded {
+		poolCtx, cancelPool = context.WithTimeout(ctx, cfg.GlobalTimeout)
+	} else {
+		poolCtx, cancelPool = context.WithCancel(ctx)
+	}
+
+	outCh := make(chan Result[R], poolBufferSize(cfg))
+	jobCh := make(chan Job[T])
+
+	sendResult := func(result Result[R]) {
+		outCh <- result
+	}
+
+	workerWG := runWorkers(poolCtx, jobCh, ignoreIndex(workerFunc), globalSemaphore, cfg, sendResult, cancelPool)
+
+	// Feeder
+	var feederWG sync.WaitGroup
+	feederWG.Add(1)
+	go func() {
+		defer feederWG.Done()
+		defer close(jobCh)
+
+		seen := newIDTracker(cfg.AutoReindex)
+		position := 0
+		for job := range in {
+			if cfg.AutoReindex {
+				job.ID = position
+			}
+			position++
+
+			// Running duplicate check
+			if seen.duplicate(job.ID) {
+				sendResult(Result[R]{ID: job.ID, Err: fmt.Errorf("duplicate job ID detected: %d (job rejected)", job.ID)})
+				continue
+			}
+
+			select {
+			case jobCh <- job:
+			case <-poolCtx.Done():
+				// Keep consuming in so the producer is never blocked
+				sendResult(Result[R]{ID: job.ID, Err: ErrSkipped})
+			}
+		}
+	}()
+
+	// Finalizer
+	go func() {
+		feederWG.Wait()
+		workerWG.Wait()
+		cancelPool() // Ensure cleanup
+		close(outCh)
+	}()
+
+	return outCh
+}
+
+// idTracker detects repeated job IDs on a stream. A nil seen set disables
+// tracking, for reindexed IDs that are unique by construction.
+type idTracker struct {
+	seen map[int]struct{}
+}
+
+// newIDTracker returns a tracker that keeps every ID, or none when autoReindex is set.
+func newIDTracker(autoReindex bool) *idTracker {
+	if autoReindex {
+		return &idTracker{}
+	}
+	return &idTracker{seen: make(map[int]struct{})}
+}
+
+// duplicate reports whether id was already seen, recording it otherwise.
+func (t *idTracker) duplicate(id int) bool {
+	if t.seen == nil {
+		return false
+	}
+	if _, dup := t.seen[id]; dup {
+		return true
+	}
+	t.seen[id] = struct{}{}
+	return false
+}
@@ -0,0 +1,143 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRunGenericWorkerPoolChan verifies every job read from the channel yields a result
+func TestRunGenericWorkerPoolChan(t *testing.T) {
+	in := make(chan Job[int])
+	go func() {
+		defer close(in)
+		for i := 0; i < 50; i++ {
+			in <- Job[int]{ID: i, Data: i}
+		}
+	}()
+
+	results := RunGenericWorkerPoolChan(context.Background(), in, func(ctx context.Context, n int) (int, error) {
+		return n * 2, nil
+	}, nil, WorkerPoolConfig{NumWorkers: 4})
+
+	seen := make(map[int]bool)
+	for res := range results {
+		if res.Err != nil || res.Value != res.ID*2 {
+			t.Errorf("Unexpected result: %+v", res)
+		}
+		seen[res.ID] = true
+	}
+	if len(seen) != 50 {
+		t.Errorf("Expected 50 results, got %d", len(seen))
+	}
+}
+
+// TestRunGenericWorkerPoolChanDuplicate verifies only the repeated job is rejected
+func TestRunGenericWorkerPoolChanDuplicate(t *testing.T) {
+	in := make(chan Job[string], 3)
+	in <- Job[string]{ID: 1, Data: "a"}
+	in <- Job[string]{ID: 1, Data: "b"}
+	in <- Job[string]{ID: 2, Data: "c"}
+	close(in)
+
+	results := RunGenericWorkerPoolChan(context.Background(), in, func(ctx context.Context, s string) (string, error) {
+		return s, nil
+	}, nil, WorkerPoolConfig{NumWorkers: 1})
+
+	var ok, rejected int
+	for res := range results {
+		switch {
+		case res.Err == nil:
+			ok++
+		case strings.Contains(res.Err.Error(), "duplicate job ID"):
+			rejected++
+		default:
+			t.Errorf("Unexpected error: %v", res.Err)
+		}
+	}
+	if ok != 2 || rejected != 1 {
+		t.Errorf("Expected 2 ok and 1 rejected, got %d ok and %d rejected", ok, rejected)
+	}
+}
+
+// TestRunGenericWorkerPoolChanAutoReindex verifies IDs follow arrival order
+func TestRunGenericWorkerPoolChanAutoReindex(t *testing.T) {
+	in := make(chan Job[string], 3)
+	for _, s := range []string{"a", "b", "c"} {
+		in <- Job[string]{ID: 7, Data: s}
+	}
+	close(in)
+
+	results := RunGenericWorkerPoolChan(context.Background(), in, func(ctx context.Context, s string) (string, error) {
+		return s, nil
+	}, nil, WorkerPoolConfig{NumWorkers: 2, AutoReindex: true})
+
+	for res := range results {
+		if res.Err != nil || res.Value != []string{"a", "b", "c"}[res.ID] {
+			t.Errorf("Unexpected result: %+v", res)
+		}
+	}
+}
+
+// TestIDTracker verifies IDs are tracked for caller IDs but not kept under AutoReindex
+func TestIDTracker(t *testing.T) {
+	tracked := newIDTracker(false)
+	if tracked.duplicate(1) || !tracked.duplicate(1) {
+		t.Error("Expected the second ID 1 to be reported as a duplicate")
+	}
+
+	reindexed := newIDTracker(true)
+	for i := 0; i < 1000; i++ {
+		if reindexed.duplicate(i) {
+			t.Fatalf("Unexpected duplicate %d under AutoReindex", i)
+		}
+	}
+	if len(reindexed.seen) != 0 {
+		t.Errorf("Expected no IDs kept under AutoReindex, got %d", len(reindexed.seen))
+	}
+}
+
+// TestRunGenericWorkerPoolChanCancel verifies jobs after cancellation are skipped
+// and the producer is never blocked
+func TestRunGenericWorkerPoolChanCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan Job[int])
+
+	results := RunGenericWorkerPoolChan(ctx, in, func(ctx context.Context, n int) (int, error) {
+		return n, nil
+	}, nil, WorkerPoolConfig{NumWorkers: 2, ResultBuffer: 1})
+
+	produced := make(chan struct{})
+	go func() {
+		defer close(produced)
+		defer close(in)
+		for i := 0; i < 20; i++ {
+			if i == 5 {
+				cancel()
+			}
+			in <- Job[int]{ID: i, Data: i}
+		}
+	}()
+
+	count, skipped := 0, 0
+	for res := range results {
+		count++
+		if errors.Is(res.Err, ErrSkipped) {
+			skipped++
+		}
+	}
+
+	select {
+	case <-produced:
+	case <-time.After(time.Second):
+		t.Fatal("Producer was blocked")
+	}
+	if count != 20 {
+		t.Errorf("Expected 20 results, got %d", count)
+	}
+	if skipped < 14 {
+		t.Errorf("Expected jobs after cancel to be skipped, got %d skipped", skipped)
+	}
+}
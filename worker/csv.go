@@ -0,0 +1,85 @@
+package worker
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// RowError describes a CSV row that could not be parsed.
+// ID is the job ID the row would have had, so it can be merged into the
+// pool's results as Result[R]{ID: e.ID, Err: e}.
+type RowError struct {
+	ID   int   // sequential data-row index (header excluded)
+	Line int   // 1-based line number in the input
+	Err  error // error returned by parse
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("csv line %d: %v", e.Line, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// JobsFromCSV reads every row from r and converts it into a Job using parse.
+// IDs are sequential data-row indexes starting at 0, like JobsFromSlice.
+// Rows may have varying field counts; validate them in parse.
+//
+// A row that parse rejects does not become a job: it is returned as a RowError
+// and keeps its ID, so the caller can report it alongside the pool's results.
+// A malformed CSV stream (e.g. a bare quote) or read error aborts the import
+// and is returned as err.
+//
+// Memory: every parsed row is held in memory until the batch runs, so
+// peak usage grows with file size. For very large files, read with
+// encoding/csv directly and feed a Pool via Submit instead.
+//
+// Example:
+//
+//	jobs, rowErrs, err := worker.JobsFromCSV(file, true, func(rec []string) (User, error) {
+//	    if len(rec) != 2 {
+//	        return User{}, fmt.Errorf("expected 2 fields, got %d", len(rec))
+//	    }
+//	    return User{Name: rec[0], Email: rec[1]}, nil
+//	})
+func JobsFromCSV[T any](
+	r io.Reader,
+	skipHeader bool,
+	parse func([]string) (T, error),
+) ([]Job[T], []*RowError, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // leave field-count validation to parse
+
+	if skipHeader {
+		if _, err := reader.Read(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, nil, nil
+			}
+			return nil, nil, fmt.Errorf("read csv header: %w", err)
+		}
+	}
+
+	var jobs []Job[T]
+	var rowErrs []*RowError
+	for id := 0; ; id++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("read csv: %w", err)
+		}
+
+		data, err := parse(record)
+		if err != nil {
+			line, _ := reader.FieldPos(0)
+			rowErrs = append(rowErrs, &RowError{ID: id, Line: line, Err: err})
+			continue
+		}
+		jobs = append(jobs, Job[T]{ID: id, Data: data})
+	}
+	return jobs, rowErrs, nil
+}
@@ -0,0 +1,73 @@
+package worker
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestJobsFromCSV verifies header skipping, sequential IDs, and row errors
+func TestJobsFromCSV(t *testing.T) {
+	input := "name,age\nbudi,30\nsiti,abc\nandi,25\n"
+	parse := func(rec []string) (int, error) {
+		return strconv.Atoi(rec[1])
+	}
+
+	jobs, rowErrs, err := JobsFromCSV(strings.NewReader(input), true, parse)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(jobs) != 2 {
+		t.Fatalf("Expected 2 jobs, got %d", len(jobs))
+	}
+	if jobs[0].ID != 0 || jobs[0].Data != 30 {
+		t.Errorf("Unexpected first job: %+v", jobs[0])
+	}
+	if jobs[1].ID != 2 || jobs[1].Data != 25 {
+		t.Errorf("Unexpected second job: %+v", jobs[1])
+	}
+
+	if len(rowErrs) != 1 {
+		t.Fatalf("Expected 1 row error, got %d", len(rowErrs))
+	}
+	if rowErrs[0].ID != 1 || rowErrs[0].Line != 3 {
+		t.Errorf("Unexpected row error: ID=%d Line=%d", rowErrs[0].ID, rowErrs[0].Line)
+	}
+	var numErr *strconv.NumError
+	if !errors.As(rowErrs[0], &numErr) {
+		t.Errorf("Expected row error to wrap *strconv.NumError, got %v", rowErrs[0].Err)
+	}
+}
+
+// TestJobsFromCSVNoHeader verifies the first row is parsed when skipHeader is false
+func TestJobsFromCSVNoHeader(t *testing.T) {
+	jobs, _, err := JobsFromCSV(strings.NewReader("a\nb\n"), false, func(rec []string) (string, error) {
+		return rec[0], nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(jobs) != 2 || jobs[0].Data != "a" {
+		t.Errorf("Expected jobs [a b], got %+v", jobs)
+	}
+
+	// Empty input with header skip is not an error
+	jobs, _, err = JobsFromCSV(strings.NewReader(""), true, func(rec []string) (string, error) {
+		return rec[0], nil
+	})
+	if err != nil || len(jobs) != 0 {
+		t.Errorf("Expected no jobs and no error, got %d jobs, err=%v", len(jobs), err)
+	}
+}
+
+// TestJobsFromCSVMalformed verifies malformed CSV aborts the import
+func TestJobsFromCSVMalformed(t *testing.T) {
+	_, _, err := JobsFromCSV(strings.NewReader("a,\"b\nc"), false, func(rec []string) (string, error) {
+		return rec[0], nil
+	})
+	if err == nil {
+		t.Error("Expected error for malformed CSV")
+	}
+}
@@ -0,0 +1,43 @@
+package worker
+
+// JobsFromSlice wraps each item into a Job using its index as the ID.
+// IDs are therefore unique and sequential (0..len-1), which always passes
+// the pool's duplicate-ID check and lets Result.ID index back into items.
+//
+// Example:
+//
+//	jobs := worker.JobsFromSlice(users)
+//	results := worker.RunGenericWorkerPoolStream(ctx, jobs, sendEmail, nil, cfg)
+func JobsFromSlice[T any](items []T) []Job[T] {
+	jobs := make([]Job[T], len(items))
+	for i, item := range items {
+		jobs[i] = Job[T]{ID: i, Data: item}
+	}
+	return jobs
+}
+
+// Chunk splits items into consecutive batches of at most size elements.
+// The last batch holds the remainder. Returns nil if size <= 0 or items is empty.
+// Batches share the backing array of items but are capacity-capped, so
+// appending to one batch never overwrites its neighbour.
+//
+// Example:
+//
+//	batches := worker.Chunk(rows, 500)
+//	jobs := worker.JobsFromSlice(batches) // one job per 500 rows
+func Chunk[T any](items []T, size int) [][]T {
+	if size <= 0 || len(items) == 0 {
+		return nil
+	}
+
+	chunks := make([][]T, 0, (len(items)+size-1)/size)
+	for start := 0; start < len(items); start += size {
+		end := start + size
+		if end > len(items) {
+			end = len(items)
+		}
+		// Full slice expression caps capacity at the chunk boundary
+		chunks = append(chunks, items[start:end:end])
+	}
+	return chunks
+}
@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"testing"
+)
+
+// TestJobsFromSlice verifies sequential IDs matching the input index
+func TestJobsFromSlice(t *testing.T) {
+	items := []string{"a", "b", "c"}
+	jobs := JobsFromSlice(items)
+
+	if len(jobs) != len(items) {
+		t.Fatalf("Expected %d jobs, got %d", len(items), len(jobs))
+	}
+	for i, job := range jobs {
+		if job.ID != i {
+			t.Errorf("Expected ID %d, got %d", i, job.ID)
+		}
+		if job.Data != items[i] {
+			t.Errorf("Expected data %q, got %q", items[i], job.Data)
+		}
+	}
+
+	if got := JobsFromSlice([]int{}); len(got) != 0 {
+		t.Errorf("Expected no jobs for empty input, got %d", len(got))
+	}
+}
+
+// TestChunk verifies batch sizes, remainder, and invalid sizes
+func TestChunk(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7}
+
+	chunks := Chunk(items, 3)
+	if len(chunks) != 3 {
+		t.Fatalf("Expected 3 chunks, got %d", len(chunks))
+	}
+	if len(chunks[0]) != 3 || len(chunks[1]) != 3 || len(chunks[2]) != 1 {
+		t.Errorf("Unexpected chunk sizes: %v", chunks)
+	}
+	if chunks[2][0] != 7 {
+		t.Errorf("Expected remainder chunk [7], got %v", chunks[2])
+	}
+
+	// Appending to a chunk must not overwrite the next one
+	chunks[0] = append(chunks[0], 99)
+	if chunks[1][0] != 4 {
+		t.Errorf("Append leaked into neighbour chunk: %v", chunks[1])
+	}
+
+	if got := Chunk(items, 0); got != nil {
+		t.Errorf("Expected nil for size 0, got %v", got)
+	}
+	if got := Chunk([]int{}, 5); got != nil {
+		t.Errorf("Expected nil for empty input, got %v", got)
+	}
+	if got := Chunk(items, 10); len(got) != 1 || len(got[0]) != len(items) {
+		t.Errorf("Expected single chunk when size exceeds length, got %v", got)
+	}
+}
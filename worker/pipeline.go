@@ -0,0 +1,134 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Stage is one step of a Pipeline. It receives the previous stage's output
+// (or the job Data for the first stage) and returns the input for the next.
+type Stage func(context.Context, any) (any, error)
+
+// Pipeline runs every job through stages in sequence (e.g. validate → transform → persist).
+// Each stage is its own worker pool with cfg.NumWorkers workers, so an item can be
+// persisted while later items are still being validated. The job ID is preserved
+// through all stages and every input job yields exactly one Result.
+//
+// Behavior:
+//   - An error (or panic) in a stage short-circuits that item: its Result carries
+//     the error and later stages never see it.
+//   - GlobalTimeout bounds the whole pipeline; WorkerTimeout and Job.Timeout apply per stage.
+//   - StopOnError (and StopOnPanic for panics) cancels the whole pipeline; pending items get ErrSkipped.
+//   - Duplicate job IDs reject all jobs unless AutoReindex is set, as in RunGenericWorkerPoolStream.
+//   - Batches larger than MaxJobs are rejected up front with ErrTooManyJobs.
+//   - The Observer sees every stage, so each ID is reported once per stage it reaches.
+//   - With no stages, each job's Data is returned as its Value.
+//
+// Example:
+//
+//	results := worker.Pipeline(ctx, jobs, cfg,
+//	    func(ctx context.Context, in any) (any, error) { return validate(in.(Row)) },
+//	    func(ctx context.Context, in any) (any, error) { return transform(in.(Row)) },
+//	    func(ctx context.Context, in any) (any, error) { return persist(ctx, in.(Entity)) },
+//	)
+//	for res := range results { ... }
+func Pipeline[T any](
+	ctx context.Context,
+	input []Job[T],
+	cfg WorkerPoolConfig,
+	stages ...Stage,
+) <-chan Result[any] {
+	// Reject oversized batches before copying them
+	if out := rejectOversized[T, any](input, cfg); out != nil {
+		return out
+	}
+
+	if len(stages) == 0 {
+		stages = []Stage{func(_ context.Context, in any) (any, error) { return in, nil }}
+	}
+
+	// One deadline and cancellation for every stage
+	pipeCtx, cancelPipe := context.WithTimeout(ctx, withDefaults(cfg).GlobalTimeout)
+
+	// Wrap stages so StopOnError/StopOnPanic cancel the pipeline, not just the failing stage
+	wrap := func(stage Stage) func(context.Context, any) (any, error) {
+		return func(ctx context.Context, in any) (out any, err error) {
+			// Cancel on panic without recovering: the panic keeps unwinding with
+			// its original stack, so the worker's OnPanic sees the failing stage
+			returned := false
+			if cfg.StopOnPanic {
+				defer func() {
+					if !returned {
+						cancelPipe()
+					}
+				}()
+			}
+			out, err = stage(ctx, in)
+			returned = true
+			if err != nil && cfg.StopOnError {
+				cancelPipe()
+			}
+			return out, err
+		}
+	}
+
+	// Keep per-job timeouts for later stages
+	timeouts := make(map[int]time.Duration, len(input))
+	jobs := make([]Job[any], len(input))
+	for i, job := range input {
+		id := job.ID
+		if cfg.AutoReindex {
+			id = i
+		}
+		jobs[i] = Job[any]{ID: id, Data: job.Data, Timeout: job.Timeout}
+		if job.Timeout > 0 {
+			timeouts[id] = job.Timeout
+		}
+	}
+
+	out := make(chan Result[any], resultBufferSize(cfg, len(input)))
+
+	// First stage validates IDs and runs the batch
+	prev := RunGenericWorkerPoolStream(pipeCtx, jobs, wrap(stages[0]), nil, cfg)
+
+	// Later stages are long-lived pools bounded by pipeCtx
+	stageCfg := cfg
+	stageCfg.GlobalTimeout = 0
+	var forwardWG sync.WaitGroup
+	for _, stage := range stages[1:] {
+		pool := NewPool(pipeCtx, wrap(stage), nil, stageCfg)
+
+		forwardWG.Add(1)
+		go func(in <-chan Result[any]) {
+			defer forwardWG.Done()
+			for res := range in {
+				// Failed items leave the pipeline here
+				if res.Err != nil {
+					out <- res
+					continue
+				}
+				job := Job[any]{ID: res.ID, Data: res.Value, Timeout: timeouts[res.ID]}
+				if err := pool.Submit(job); err != nil {
+					out <- Result[any]{ID: res.ID, Err: ErrSkipped}
+				}
+			}
+			_ = pool.Shutdown(context.Background())
+		}(prev)
+
+		prev = pool.Results()
+	}
+
+	// Finalizer: last stage output, then wait for forwarders still reporting
+	// failures (a cancelled pool can close before its feeder is done)
+	go func() {
+		defer cancelPipe()
+		for res := range prev {
+			out <- res
+		}
+		forwardWG.Wait()
+		close(out)
+	}()
+
+	return out
+}
@@ -0,0 +1,205 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestPipeline verifies stages run in order and IDs are preserved
+func TestPipeline(t *testing.T) {
+	errOdd := errors.New("odd")
+
+	validate := func(ctx context.Context, in any) (any, error) {
+		if in.(int)%2 != 0 {
+			return nil, errOdd
+		}
+		return in, nil
+	}
+	double := func(ctx context.Context, in any) (any, error) {
+		return in.(int) * 2, nil
+	}
+	render := func(ctx context.Context, in any) (any, error) {
+		return strconv.Itoa(in.(int)), nil
+	}
+
+	jobs := JobsFromSlice([]int{0, 1, 2, 3, 4, 5})
+	results := Pipeline(context.Background(), jobs, WorkerPoolConfig{NumWorkers: 2}, validate, double, render)
+
+	got := make(map[int]Result[any])
+	for res := range results {
+		if _, dup := got[res.ID]; dup {
+			t.Errorf("Duplicate result for ID %d", res.ID)
+		}
+		got[res.ID] = res
+	}
+
+	if len(got) != len(jobs) {
+		t.Fatalf("Expected %d results, got %d", len(jobs), len(got))
+	}
+	for id, res := range got {
+		if id%2 != 0 {
+			if !errors.Is(res.Err, errOdd) {
+				t.Errorf("ID %d: expected errOdd, got %v", id, res.Err)
+			}
+			continue
+		}
+		if res.Err != nil || res.Value != strconv.Itoa(id*2) {
+			t.Errorf("ID %d: expected %q, got %v (err %v)", id, strconv.Itoa(id*2), res.Value, res.Err)
+		}
+	}
+}
+
+// TestPipelineNoStages verifies Data passes through unchanged
+func TestPipelineNoStages(t *testing.T) {
+	count := 0
+	for res := range Pipeline(context.Background(), JobsFromSlice([]string{"a", "b"}), WorkerPoolConfig{}) {
+		if res.Err != nil || res.Value != []string{"a", "b"}[res.ID] {
+			t.Errorf("Unexpected result: %+v", res)
+		}
+		count++
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 results, got %d", count)
+	}
+}
+
+// TestPipelineStopOnError verifies a failure in a later stage cancels the pipeline
+func TestPipelineStopOnError(t *testing.T) {
+	errFail := errors.New("persist failed")
+
+	pass := func(ctx context.Context, in any) (any, error) { return in, nil }
+	// The first item to reach persist fails, the rest would take a second
+	var calls int32
+	persist := func(ctx context.Context, in any) (any, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return nil, errFail
+		}
+		select {
+		case <-time.After(time.Second):
+			return in, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	start := time.Now()
+	jobs := JobsFromSlice(make([]int, 20))
+	for i := range jobs {
+		jobs[i].Data = i
+	}
+
+	count, failed := 0, 0
+	for res := range Pipeline(context.Background(), jobs, WorkerPoolConfig{NumWorkers: 2, StopOnError: true}, pass, persist) {
+		count++
+		if res.Err != nil {
+			failed++
+		}
+	}
+
+	if count != len(jobs) {
+		t.Errorf("Expected %d results, got %d", len(jobs), count)
+	}
+	if failed != len(jobs) {
+		t.Errorf("Expected every job to fail or be skipped, got %d failures", failed)
+	}
+	if elapsed := time.Since(start); elapsed > 900*time.Millisecond {
+		t.Errorf("Pipeline was not cancelled promptly: %v", elapsed)
+	}
+}
+
+// TestPipelineDuplicateIDs verifies duplicate IDs reject all jobs
+func TestPipelineDuplicateIDs(t *testing.T) {
+	jobs := []Job[int]{{ID: 1, Data: 1}, {ID: 1, Data: 2}}
+	pass := func(ctx context.Context, in any) (any, error) { return in, nil }
+
+	for res := range Pipeline(context.Background(), jobs, WorkerPoolConfig{}, pass, pass) {
+		if res.Err == nil {
+			t.Errorf("Expected duplicate ID error, got %+v", res)
+		}
+	}
+}
+
+// TestPipelineMaxJobs verifies oversized batches are rejected up front
+func TestPipelineMaxJobs(t *testing.T) {
+	jobs := []Job[int]{{ID: 1, Data: 1}, {ID: 2, Data: 2}, {ID: 3, Data: 3}}
+	pass := func(ctx context.Context, in any) (any, error) { return in, nil }
+
+	count := 0
+	for res := range Pipeline(context.Background(), jobs, WorkerPoolConfig{MaxJobs: 2}, pass, pass) {
+		count++
+		if !errors.Is(res.Err, ErrTooManyJobs) {
+			t.Errorf("Expected ErrTooManyJobs, got %+v", res)
+		}
+	}
+	if count != len(jobs) {
+		t.Errorf("Expected %d results, got %d", len(jobs), count)
+	}
+}
+
+// TestPipelineStopOnPanic verifies a panicking stage cancels the pipeline and
+// OnPanic receives the stack of the failing stage, not of the pipeline wrapper
+func TestPipelineStopOnPanic(t *testing.T) {
+	pass := func(ctx context.Context, in any) (any, error) { return in, nil }
+	var calls int32
+	explode := func(ctx context.Context, in any) (any, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			panicInStage()
+		}
+		select {
+		case <-time.After(time.Second):
+			return in, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	var stacks sync.Map
+	cfg := WorkerPoolConfig{
+		NumWorkers:  2,
+		StopOnPanic: true,
+		OnPanic: func(id int, recovered any, stack []byte) {
+			stacks.Store(id, string(stack))
+		},
+	}
+
+	start := time.Now()
+	jobs := JobsFromSlice(make([]int, 20))
+	panics := 0
+	for res := range Pipeline(context.Background(), jobs, cfg, pass, explode) {
+		if res.Err == nil {
+			t.Errorf("Job %d: expected failure or skip after panic", res.ID)
+		}
+		if res.Err != nil && strings.HasPrefix(res.Err.Error(), "panic: ") {
+			panics++
+		}
+	}
+
+	if panics != 1 {
+		t.Errorf("Expected 1 panic result, got %d", panics)
+	}
+	if elapsed := time.Since(start); elapsed > 900*time.Millisecond {
+		t.Errorf("Pipeline was not cancelled promptly: %v", elapsed)
+	}
+	seen := 0
+	stacks.Range(func(_, v any) bool {
+		seen++
+		if !strings.Contains(v.(string), "panicInStage") {
+			t.Errorf("OnPanic stack does not include the failing stage:\n%s", v)
+		}
+		return true
+	})
+	if seen != 1 {
+		t.Errorf("Expected OnPanic to be called once, got %d", seen)
+	}
+}
+
+// panicInStage is a named frame for TestPipelineStopOnPanic to find in the stack
+func panicInStage() {
+	panic("stage exploded")
+}
@@ -0,0 +1,169 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrPoolClosed is returned by Pool.Submit once the pool stops accepting jobs.
+var ErrPoolClosed = errors.New("worker pool is shut down")
+
+// Pool is a long-lived worker pool that accepts jobs over time, as opposed to
+// RunGenericWorkerPoolStream which processes a fixed batch.
+// It shares the same worker implementation (timeouts, semaphore, panic recovery,
+// StopOnError, StopOnPanic, Observer).
+//
+// Differences from the batch API:
+//   - GlobalTimeout is applied only when set explicitly; otherwise the pool
+//     lives until its parent context is cancelled or Shutdown is called.
+//   - Job IDs are not checked for duplicates; keep them unique yourself.
+//     AutoReindex is ignored since there is no input slice to index into.
+//   - Results MUST be drained from Results() or workers will block.
+//
+// Example:
+//
+//	pool := worker.NewPool(ctx, sendEmail, nil, worker.WorkerPoolConfig{NumWorkers: 4})
+//	go func() {
+//	    for res := range pool.Results() { ... }
+//	}()
+//	_ = pool.Submit(worker.Job[Email]{ID: 1, Data: email})
+//	// on SIGTERM:
+//	_ = pool.Shutdown(shutdownCtx)
+type Pool[T any, R any] struct {
+	ctx     context.Context // pool context (parent + optional GlobalTimeout)
+	cancel  context.CancelFunc
+	jobCh   chan Job[T]
+	results chan Result[R]
+
+	mu        sync.RWMutex  // held (read) by Submit while sending on jobCh
+	quit      chan struct{} // closed when intake stops
+	closeOnce sync.Once
+	done      chan struct{} // closed after all workers exit and results is closed
+}
+
+// NewPool starts the workers and returns a pool ready to accept jobs.
+func NewPool[T any, R any](
+	ctx context.Context,
+	workerFunc func(context.Context, T) (R, error),
+	globalSemaphore chan struct{},
+	cfg WorkerPoolConfig,
+) *Pool[T, R] {
+	// Only bound the pool lifetime if the caller asked for it
+	bounded := cfg.GlobalTimeout > 0
+	cfg = withDefaults(cfg)
+
+	var poolCtx context.Context
+	var cancelPool context.CancelFunc
+	if bounded {
+		poolCtx, cancelPool = context.WithTimeout(ctx, cfg.GlobalTimeout)
+	} else {
+		poolCtx, cancelPool = context.WithCancel(ctx)
+	}
+
+	p := &Pool[T, R]{
+		ctx:     poolCtx,
+		cancel:  cancelPool,
+		jobCh:   make(chan Job[T]),
+		results: make(chan Result[R], poolBufferSize(cfg)),
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	sendResult := func(result Result[R]) {
+		p.results <- result
+	}
+
+	workerWG := runWorkers(poolCtx, p.jobCh, ignoreIndex(workerFunc), globalSemaphore, cfg, sendResult, cancelPool)
+
+	// Stop intake automatically when the pool context ends (parent cancel, timeout, StopOnError, StopOnPanic)
+	go func() {
+		<-poolCtx.Done()
+		p.stopAccepting()
+	}()
+
+	// Finalizer
+	go func() {
+		workerWG.Wait()
+		cancelPool() // Ensure cleanup
+		close(p.results)
+		close(p.done)
+	}()
+
+	return p
+}
+
+// poolBufferSize returns the Results channel capacity: ResultBuffer when set,
+// otherwise one slot per worker.
+func poolBufferSize(cfg WorkerPoolConfig) int {
+	if cfg.ResultBuffer > 0 {
+		return cfg.ResultBuffer
+	}
+	return cfg.NumWorkers
+}
+
+// Submit hands a job to the next free worker, blocking until one accepts it.
+// Returns ErrPoolClosed after Shutdown, or the context error if the pool was
+// cancelled. A job for which Submit returns an error produces no Result.
+func (p *Pool[T, R]) Submit(job Job[T]) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	// Fast path: intake already stopped
+	select {
+	case <-p.quit:
+		return ErrPoolClosed
+	default:
+	}
+
+	select {
+	case p.jobCh <- job:
+		return nil
+	case <-p.quit:
+		return ErrPoolClosed
+	case <-p.ctx.Done():
+		return p.ctx.Err()
+	}
+}
+
+// Results returns the channel on which every accepted job's Result is delivered.
+// It is closed once the pool has fully drained.
+func (p *Pool[T, R]) Results() <-chan Result[R] {
+	return p.results
+}
+
+// Shutdown stops accepting new jobs and waits for in-flight jobs to finish.
+// If ctx expires first, the pool is cancelled (running jobs see their context
+// cancelled, queued ones are skipped) and ctx.Err() is returned.
+// Results must still be drained for the pool to finish.
+func (p *Pool[T, R]) Shutdown(ctx context.Context) error {
+	p.stopAccepting()
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		return ctx.Err()
+	}
+}
+
+// Wait blocks until the pool has fully drained after Shutdown
+// or cancellation of its context.
+func (p *Pool[T, R]) Wait() {
+	<-p.done
+}
+
+// stopAccepting closes intake exactly once. Blocked Submit calls are woken via quit
+// before jobCh is closed, so no send can ever hit a closed channel.
+func (p *Pool[T, R]) stopAccepting() {
+	p.closeOnce.Do(func() {
+		close(p.quit)
+		go func() {
+			// Wait for in-progress Submit calls to return
+			p.mu.Lock()
+			close(p.jobCh)
+			p.mu.Unlock()
+		}()
+	})
+}
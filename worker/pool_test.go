@@ -0,0 +1,123 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestPoolSubmitAndShutdown verifies every accepted job yields one result
+func TestPoolSubmitAndShutdown(t *testing.T) {
+	workerFunc := func(ctx context.Context, data int) (string, error) {
+		time.Sleep(5 * time.Millisecond)
+		return fmt.Sprintf("result-%d", data), nil
+	}
+
+	pool := NewPool(context.Background(), workerFunc, nil, WorkerPoolConfig{NumWorkers: 3})
+
+	var wg sync.WaitGroup
+	received := make(map[int]int)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for res := range pool.Results() {
+			if res.Err != nil {
+				t.Errorf("Job %d failed: %v", res.ID, res.Err)
+			}
+			received[res.ID]++
+		}
+	}()
+
+	const numJobs = 20
+	for i := 0; i < numJobs; i++ {
+		if err := pool.Submit(Job[int]{ID: i, Data: i}); err != nil {
+			t.Fatalf("Submit failed: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := pool.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	wg.Wait()
+
+	if len(received) != numJobs {
+		t.Errorf("Expected %d results, got %d", numJobs, len(received))
+	}
+	for id, n := range received {
+		if n != 1 {
+			t.Errorf("Job %d received %d times", id, n)
+		}
+	}
+
+	// Intake is closed after shutdown
+	if err := pool.Submit(Job[int]{ID: 99, Data: 99}); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("Expected ErrPoolClosed, got %v", err)
+	}
+
+	// Wait returns immediately once drained
+	pool.Wait()
+}
+
+// TestPoolShutdownDeadline verifies Shutdown honours its context deadline
+func TestPoolShutdownDeadline(t *testing.T) {
+	workerFunc := func(ctx context.Context, data int) (string, error) {
+		select {
+		case <-time.After(5 * time.Second):
+			return "done", nil
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+
+	pool := NewPool(context.Background(), workerFunc, nil, WorkerPoolConfig{NumWorkers: 1})
+	go func() {
+		for range pool.Results() {
+		}
+	}()
+
+	if err := pool.Submit(Job[int]{ID: 1, Data: 1}); err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	startTime := time.Now()
+	err := pool.Shutdown(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+
+	// The in-flight job is cancelled, so the pool drains quickly
+	pool.Wait()
+	if elapsed := time.Since(startTime); elapsed > time.Second {
+		t.Errorf("Expected pool to drain after forced shutdown, took %v", elapsed)
+	}
+}
+
+// TestPoolParentCancel verifies a cancelled parent stops intake
+func TestPoolParentCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	workerFunc := func(ctx context.Context, data int) (int, error) {
+		return data, nil
+	}
+
+	pool := NewPool(ctx, workerFunc, nil, WorkerPoolConfig{})
+	go func() {
+		for range pool.Results() {
+		}
+	}()
+
+	cancel()
+	pool.Wait()
+
+	if err := pool.Submit(Job[int]{ID: 1, Data: 1}); err == nil {
+		t.Error("Expected Submit to fail after parent cancellation")
+	}
+}
@@ -0,0 +1,177 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// MapResults applies f to the Value of every successful result and returns
+// the mapped values in input order. Results with a non-nil Err are skipped.
+//
+// Example:
+//
+//	names := worker.MapResults(results, func(u User) string { return u.Name })
+func MapResults[R any, S any](results []Result[R], f func(R) S) []S {
+	out := make([]S, 0, len(results))
+	for _, res := range results {
+		// Skip failed or skipped jobs
+		if res.Err != nil {
+			continue
+		}
+		out = append(out, f(res.Value))
+	}
+	return out
+}
+
+// PartitionResults splits results into successes and failures, preserving
+// input order within each group. Failures keep their full Result so the
+// job ID and error remain available for reporting.
+//
+// Example:
+//
+//	ok, failed := worker.PartitionResults(results)
+//	for _, f := range failed {
+//	    log.Printf("job %d failed: %v", f.ID, f.Err)
+//	}
+func PartitionResults[R any](results []Result[R]) (successes []Result[R], failures []Result[R]) {
+	for _, res := range results {
+		if res.Err != nil {
+			failures = append(failures, res)
+			continue
+		}
+		successes = append(successes, res)
+	}
+	return successes, failures
+}
+
+// Drain collects results from ch until it is closed or ctx is done.
+// On early exit it returns the results gathered so far and ctx.Err(); a
+// background goroutine keeps draining ch so the pool's workers never block
+// on a full channel and exit normally.
+//
+// Drain only stops waiting, it does not stop the work. Derive the pool's
+// context from the same parent (e.g. r.Context()) so a client disconnect also
+// cancels the pool and remaining jobs are skipped instead of run to completion.
+//
+// Example:
+//
+//	ch := worker.RunGenericWorkerPoolStream(r.Context(), jobs, process, nil, cfg)
+//	results, err := worker.Drain(r.Context(), ch)
+//	if err != nil {
+//	    return // client went away
+//	}
+func Drain[R any](ctx context.Context, ch <-chan Result[R]) ([]Result[R], error) {
+	var results []Result[R]
+	for {
+		select {
+		case res, ok := <-ch:
+			if !ok {
+				return results, nil
+			}
+			results = append(results, res)
+		case <-ctx.Done():
+			// Keep draining so workers blocked on send can finish
+			go func() {
+				for range ch {
+				}
+			}()
+			return results, ctx.Err()
+		}
+	}
+}
+
+// Stats summarizes a numeric field over a stream of results.
+// Count, Sum, Min, Max, and Mean cover successful results only;
+// Min, Max, and Mean are 0 when Count is 0.
+type Stats struct {
+	Count  int     // successful results aggregated
+	Sum    float64 // sum of extracted values
+	Min    float64 // smallest extracted value
+	Max    float64 // largest extracted value
+	Mean   float64 // Sum / Count
+	Errors int     // results with a non-nil Err (failed or skipped)
+}
+
+// Aggregate consumes ch until it is closed and returns running statistics of
+// extract(Value) in a single pass, without buffering results.
+// Errored results are excluded from the numbers and counted in Errors.
+//
+// Example:
+//
+//	stats := worker.Aggregate(results, func(r Response) float64 { return r.LatencyMS })
+//	log.Printf("avg %.1fms (min %.1f, max %.1f), %d failed", stats.Mean, stats.Min, stats.Max, stats.Errors)
+func Aggregate[R any](ch <-chan Result[R], extract func(R) float64) Stats {
+	var stats Stats
+	for res := range ch {
+		if res.Err != nil {
+			stats.Errors++
+			continue
+		}
+
+		v := extract(res.Value)
+		if stats.Count == 0 || v < stats.Min {
+			stats.Min = v
+		}
+		if stats.Count == 0 || v > stats.Max {
+			stats.Max = v
+		}
+		stats.Sum += v
+		stats.Count++
+	}
+
+	if stats.Count > 0 {
+		stats.Mean = stats.Sum / float64(stats.Count)
+	}
+	return stats
+}
+
+// Batch consumes ch until it is closed, grouping successful values into slices
+// of size and calling flush for each full batch, then once more for the
+// remainder (never with an empty slice). Each batch is a new slice, so flush
+// may keep it. A size below 1 is treated as 1.
+//
+// ch is always drained to the end, even after a flush fails, so the pool never
+// blocks. The returned error joins every failed job ("job 3: ...") and every
+// failed flush ("flush batch 2: ...") in arrival order, or is nil if all succeeded.
+//
+// Example:
+//
+//	results := worker.RunGenericWorkerPoolStream(ctx, jobs, parseRow, nil, cfg)
+//	err := worker.Batch(results, 500, func(rows []Row) error {
+//	    return db.CreateInBatches(rows, len(rows)).Error
+//	})
+func Batch[R any](ch <-chan Result[R], size int, flush func([]R) error) error {
+	if size < 1 {
+		size = 1
+	}
+
+	var errs []error
+	batchNum := 0
+	flushBatch := func(batch []R) {
+		batchNum++
+		if err := flush(batch); err != nil {
+			errs = append(errs, fmt.Errorf("flush batch %d: %w", batchNum, err))
+		}
+	}
+
+	batch := make([]R, 0, size)
+	for res := range ch {
+		if res.Err != nil {
+			errs = append(errs, fmt.Errorf("job %d: %w", res.ID, res.Err))
+			continue
+		}
+
+		batch = append(batch, res.Value)
+		if len(batch) == size {
+			flushBatch(batch)
+			batch = make([]R, 0, size)
+		}
+	}
+
+	// Remainder
+	if len(batch) > 0 {
+		flushBatch(batch)
+	}
+	return errors.Join(errs...)
+}
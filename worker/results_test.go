@@ -0,0 +1,210 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestMapResults verifies errored entries are skipped and order is kept
+func TestMapResults(t *testing.T) {
+	results := []Result[int]{
+		{ID: 1, Value: 10},
+		{ID: 2, Err: errors.New("failed")},
+		{ID: 3, Value: 30},
+		{ID: 4, Err: ErrSkipped},
+	}
+
+	doubled := MapResults(results, func(v int) int { return v * 2 })
+
+	expected := []int{20, 60}
+	if len(doubled) != len(expected) {
+		t.Fatalf("Expected %d values, got %d", len(expected), len(doubled))
+	}
+	for i := range expected {
+		if doubled[i] != expected[i] {
+			t.Errorf("Index %d: expected %d, got %d", i, expected[i], doubled[i])
+		}
+	}
+
+	if got := MapResults([]Result[int]{}, func(v int) int { return v }); len(got) != 0 {
+		t.Errorf("Expected empty slice for empty input, got %v", got)
+	}
+}
+
+// TestPartitionResults verifies successes and failures are separated
+func TestPartitionResults(t *testing.T) {
+	results := []Result[string]{
+		{ID: 1, Value: "a"},
+		{ID: 2, Err: errors.New("failed")},
+		{ID: 3, Value: "c"},
+		{ID: 4, Err: ErrSkipped},
+	}
+
+	successes, failures := PartitionResults(results)
+
+	if len(successes) != 2 || successes[0].ID != 1 || successes[1].ID != 3 {
+		t.Errorf("Unexpected successes: %+v", successes)
+	}
+	if len(failures) != 2 || failures[0].ID != 2 || failures[1].ID != 4 {
+		t.Errorf("Unexpected failures: %+v", failures)
+	}
+	if !errors.Is(failures[1].Err, ErrSkipped) {
+		t.Errorf("Expected ErrSkipped to be preserved, got %v", failures[1].Err)
+	}
+}
+
+// TestDrain verifies all results are collected when the channel closes
+func TestDrain(t *testing.T) {
+	jobs := JobsFromSlice([]int{1, 2, 3})
+	ch := RunGenericWorkerPoolStream(context.Background(), jobs, func(ctx context.Context, n int) (int, error) {
+		return n * 2, nil
+	}, nil, WorkerPoolConfig{NumWorkers: 2})
+
+	results, err := Drain(context.Background(), ch)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(results) != 3 {
+		t.Errorf("Expected 3 results, got %d", len(results))
+	}
+}
+
+// TestDrainCancelled verifies early exit on ctx cancellation and that the
+// pool still finishes instead of blocking on an unread channel
+func TestDrainCancelled(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+
+	jobs := JobsFromSlice(make([]int, 10))
+	ch := RunGenericWorkerPoolStream(context.Background(), jobs, func(ctx context.Context, n int) (int, error) {
+		<-release
+		return n, nil
+	}, nil, WorkerPoolConfig{NumWorkers: 2, ResultBuffer: 1})
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	results, err := Drain(parent, ch)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("Expected no results before release, got %d", len(results))
+	}
+
+	// Unblock workers; the background drain must consume everything so ch closes
+	close(release)
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatal("Pool did not finish after Drain returned")
+		}
+	}
+}
+
+// TestAggregate verifies single-pass stats and separate error counting
+func TestAggregate(t *testing.T) {
+	ch := make(chan Result[int], 5)
+	ch <- Result[int]{ID: 1, Value: 4}
+	ch <- Result[int]{ID: 2, Err: errors.New("failed")}
+	ch <- Result[int]{ID: 3, Value: -2}
+	ch <- Result[int]{ID: 4, Value: 10}
+	ch <- Result[int]{ID: 5, Err: ErrSkipped}
+	close(ch)
+
+	stats := Aggregate(ch, func(v int) float64 { return float64(v) })
+
+	expected := Stats{Count: 3, Sum: 12, Min: -2, Max: 10, Mean: 4, Errors: 2}
+	if stats != expected {
+		t.Errorf("Expected %+v, got %+v", expected, stats)
+	}
+
+	// Only errors: numbers stay zero
+	ch = make(chan Result[int], 1)
+	ch <- Result[int]{ID: 1, Err: ErrSkipped}
+	close(ch)
+	if stats := Aggregate(ch, func(v int) float64 { return float64(v) }); stats != (Stats{Errors: 1}) {
+		t.Errorf("Expected only Errors=1, got %+v", stats)
+	}
+}
+
+// TestBatch verifies values are flushed in fixed-size batches plus a remainder
+func TestBatch(t *testing.T) {
+	ch := make(chan Result[int], 10)
+	for i := 1; i <= 7; i++ {
+		ch <- Result[int]{ID: i, Value: i}
+	}
+	close(ch)
+
+	var batches [][]int
+	err := Batch(ch, 3, func(values []int) error {
+		batches = append(batches, values)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := [][]int{{1, 2, 3}, {4, 5, 6}, {7}}
+	if len(batches) != len(expected) {
+		t.Fatalf("Expected %d batches, got %v", len(expected), batches)
+	}
+	for i := range expected {
+		if len(batches[i]) != len(expected[i]) {
+			t.Fatalf("Batch %d: expected %v, got %v", i, expected[i], batches[i])
+		}
+		for j := range expected[i] {
+			if batches[i][j] != expected[i][j] {
+				t.Errorf("Batch %d: expected %v, got %v", i, expected[i], batches[i])
+			}
+		}
+	}
+}
+
+// TestBatchErrors verifies job and flush errors are joined and ch is fully drained
+func TestBatchErrors(t *testing.T) {
+	jobErr := errors.New("bad row")
+	flushErr := errors.New("db down")
+
+	ch := make(chan Result[int], 10)
+	ch <- Result[int]{ID: 1, Value: 1}
+	ch <- Result[int]{ID: 2, Err: jobErr}
+	ch <- Result[int]{ID: 3, Value: 3}
+	ch <- Result[int]{ID: 4, Value: 4}
+	close(ch)
+
+	flushed := 0
+	err := Batch(ch, 2, func(values []int) error {
+		flushed += len(values)
+		return flushErr
+	})
+
+	if !errors.Is(err, jobErr) || !errors.Is(err, flushErr) {
+		t.Fatalf("Expected joined job and flush errors, got %v", err)
+	}
+	if err.Error() != "job 2: bad row\nflush batch 1: db down\nflush batch 2: db down" {
+		t.Errorf("Unexpected error message: %q", err.Error())
+	}
+	if flushed != 3 {
+		t.Errorf("Expected 3 values flushed, got %d", flushed)
+	}
+	if len(ch) != 0 {
+		t.Errorf("Expected channel drained, %d left", len(ch))
+	}
+
+	// No successes: flush is never called
+	empty := make(chan Result[int])
+	close(empty)
+	if err := Batch(empty, 0, func([]int) error { t.Error("unexpected flush"); return nil }); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+)
+
+// ResultSink receives pool results as they complete, e.g. to append them to a
+// file or insert them into a database without holding the batch in memory.
+// Write is called from a single goroutine, so implementations need not be
+// safe for concurrent use.
+type ResultSink[R any] interface {
+	// Write persists one result. Results with a non-nil Err are written too,
+	// so the sink can record failures alongside successes.
+	Write(res Result[R]) error
+}
+
+// RunGenericWorkerPoolToSink runs jobs like RunGenericWorkerPoolStream and writes
+// every result to sink as it arrives, in completion order. It returns once all
+// results are written (or dropped after a sink failure, see below).
+//
+// Behavior:
+//   - Unless ResultBuffer is set, the result buffer is NumWorkers instead of
+//     len(jobs), so memory stays flat regardless of batch size.
+//   - A failed sink Write does not stop the run: later results are still
+//     written and the first sink error is returned, wrapped with the job ID.
+//   - With StopOnError, a failed Write also cancels the pool and no further
+//     results are written; pending jobs are skipped.
+//   - Job errors are not returned; they reach the sink in Result.Err.
+//
+// Example:
+//
+//	enc := json.NewEncoder(file)
+//	sink := worker.SinkFunc[Row](func(res worker.Result[Row]) error { return enc.Encode(res) })
+//	err := worker.RunGenericWorkerPoolToSink(ctx, jobs, process, nil, cfg, sink)
+func RunGenericWorkerPoolToSink[T any, R any](
+	ctx context.Context,
+	jobs []Job[T],
+	workerFunc func(context.Context, T) (R, error),
+	globalSemaphore chan struct{},
+	cfg WorkerPoolConfig,
+	sink ResultSink[R],
+) error {
+	// Results are consumed immediately, so a small buffer is enough
+	if cfg.ResultBuffer <= 0 {
+		cfg.ResultBuffer = withDefaults(cfg).NumWorkers
+	}
+
+	poolCtx, cancelPool := context.WithCancel(ctx)
+	defer cancelPool()
+
+	var firstErr error
+	stopped := false
+	for res := range RunGenericWorkerPoolStream(poolCtx, jobs, workerFunc, globalSemaphore, cfg) {
+		// Keep draining after a stop so workers can exit
+		if stopped {
+			continue
+		}
+
+		if err := sink.Write(res); err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("result sink: job %d: %w", res.ID, err)
+			}
+			if cfg.StopOnError {
+				stopped = true
+				cancelPool()
+			}
+		}
+	}
+	return firstErr
+}
+
+// SinkFunc adapts a plain function to the ResultSink interface.
+type SinkFunc[R any] func(res Result[R]) error
+
+// Write calls f(res).
+func (f SinkFunc[R]) Write(res Result[R]) error {
+	return f(res)
+}
@@ -0,0 +1,101 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// sliceSink collects results without any locking (run with -race)
+type sliceSink struct {
+	results []Result[int]
+	failID  int
+}
+
+func (s *sliceSink) Write(res Result[int]) error {
+	if res.ID == s.failID {
+		return errors.New("disk full")
+	}
+	s.results = append(s.results, res)
+	return nil
+}
+
+// TestRunToSink verifies every result reaches the sink from a single goroutine
+func TestRunToSink(t *testing.T) {
+	jobs := make([]Job[int], 50)
+	for i := range jobs {
+		jobs[i] = Job[int]{ID: i + 1, Data: i}
+	}
+
+	sink := &sliceSink{failID: -1}
+	err := RunGenericWorkerPoolToSink(context.Background(), jobs, func(ctx context.Context, n int) (int, error) {
+		if n == 7 {
+			return 0, errors.New("bad row")
+		}
+		return n * 2, nil
+	}, nil, WorkerPoolConfig{NumWorkers: 4}, sink)
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(sink.results) != len(jobs) {
+		t.Fatalf("Expected %d results, got %d", len(jobs), len(sink.results))
+	}
+	// Job errors are written to the sink, not returned
+	failed := 0
+	for _, res := range sink.results {
+		if res.Err != nil {
+			failed++
+		}
+	}
+	if failed != 1 {
+		t.Errorf("Expected 1 failed result in sink, got %d", failed)
+	}
+}
+
+// TestRunToSinkWriteError verifies sink errors are returned and optionally stop the pool
+func TestRunToSinkWriteError(t *testing.T) {
+	jobs := make([]Job[int], 20)
+	for i := range jobs {
+		jobs[i] = Job[int]{ID: i + 1, Data: i}
+	}
+
+	t.Run("continues without StopOnError", func(t *testing.T) {
+		sink := &sliceSink{failID: 3}
+		err := RunGenericWorkerPoolToSink(context.Background(), jobs, func(ctx context.Context, n int) (int, error) {
+			return n, nil
+		}, nil, WorkerPoolConfig{NumWorkers: 2}, sink)
+
+		if err == nil || err.Error() != "result sink: job 3: disk full" {
+			t.Errorf("Expected wrapped sink error, got %v", err)
+		}
+		if len(sink.results) != len(jobs)-1 {
+			t.Errorf("Expected %d written results, got %d", len(jobs)-1, len(sink.results))
+		}
+	})
+
+	t.Run("cancels with StopOnError", func(t *testing.T) {
+		var ran atomic.Int32
+		sink := &sliceSink{failID: 1}
+		err := RunGenericWorkerPoolToSink(context.Background(), jobs, func(ctx context.Context, n int) (int, error) {
+			ran.Add(1)
+			select {
+			case <-time.After(20 * time.Millisecond):
+			case <-ctx.Done():
+			}
+			return n, nil
+		}, nil, WorkerPoolConfig{NumWorkers: 1, StopOnError: true}, sink)
+
+		if err == nil {
+			t.Fatal("Expected sink error")
+		}
+		if len(sink.results) != 0 {
+			t.Errorf("Expected no writes after the failure, got %d", len(sink.results))
+		}
+		if ran.Load() >= int32(len(jobs)) {
+			t.Errorf("Expected pending jobs to be skipped, %d ran", ran.Load())
+		}
+	})
+}
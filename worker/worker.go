@@ -3,14 +3,16 @@ package worker
 import (
 	"context"
 	"fmt"
+	"runtime/debug"
 	"sync"
 	"time"
 )
 
 // Job represents a generic job input.
 type Job[T any] struct {
-	ID   int // Unique identifier
-	Data T   // Payload
+	ID      int           // Unique identifier
+	Data    T             // Payload
+	Timeout time.Duration // Overrides WorkerTimeout for this job when > 0 (still bounded by GlobalTimeout)
 }
 
 // Result represents the output of processing a Job.
@@ -25,14 +27,79 @@ type WorkerPoolConfig struct {
 	NumWorkers    int           // Concurrent workers (default: 2)
 	WorkerTimeout time.Duration // Per-job timeout (default: 15s)
 	GlobalTimeout time.Duration // Global pool timeout (default: 30s)
-	StopOnError   bool          // Cancel all on first error
+	StopOnError   bool          // Cancel all on first error returned by workerFunc; panics no longer cancel unless StopOnPanic is set
+	StopOnPanic   bool          // Cancel all on first panic in workerFunc (independent of StopOnError)
+	Observer      Observer      // Optional hooks around each job (nil = no-op)
+	ResultBuffer  int           // Caps the result channel buffer (default: len(jobs)); consumer must keep draining
+	AutoReindex   bool          // Ignore Job.ID and use the input index instead; Result.ID is then the position in jobs
+
+	// MaxJobs rejects batches larger than this many jobs before anything is
+	// allocated for them: every job gets an ErrTooManyJobs Result (default: 0 = unlimited).
+	// Ignored by NewPool and RunGenericWorkerPoolChan, which never hold a whole batch.
+	MaxJobs int
+
+	// OnPanic is called when workerFunc panics, with the recovered value and the
+	// goroutine stack, before the "panic: ..." error Result is emitted (nil = no-op).
+	// Called concurrently from multiple workers.
+	OnPanic func(id int, recovered any, stack []byte)
+}
+
+// Observer receives lifecycle events for every job the pool actually runs.
+// Methods are called concurrently from multiple workers, so implementations
+// must be safe for concurrent use (e.g. Prometheus counters/histograms).
+// Jobs that are skipped before reaching a worker do not trigger any event.
+//
+// Observer panics are kept apart from job panics: they never trigger OnPanic or
+// StopOnPanic. A panic in OnJobStart fails that job without running it; a panic
+// in OnJobEnd is ignored, so a metrics bug never changes a job's Result.
+type Observer interface {
+	// OnJobStart is called right before workerFunc is invoked.
+	OnJobStart(id int)
+	// OnJobEnd is called after workerFunc returns (or panics) with the
+	// elapsed time and the error placed in the Result.
+	OnJobEnd(id int, d time.Duration, err error)
+}
+
+// observeStart calls obs.OnJobStart, returning a panic in it as an error.
+func observeStart(obs Observer, id int) (err error) {
+	if obs == nil {
+		return nil
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("observer: OnJobStart panicked: %v", r)
+		}
+	}()
+	obs.OnJobStart(id)
+	return nil
+}
+
+// observeEnd calls obs.OnJobEnd, discarding a panic in it.
+func observeEnd(obs Observer, id int, d time.Duration, err error) {
+	if obs == nil {
+		return
+	}
+	defer func() { _ = recover() }()
+	obs.OnJobEnd(id, d, err)
 }
 
 // ErrSkipped indicates a job was not processed.
 var ErrSkipped = fmt.Errorf("job not processed (cancelled or skipped)")
 
+// ErrTooManyJobs indicates a batch was rejected because it exceeds MaxJobs.
+var ErrTooManyJobs = fmt.Errorf("batch exceeds MaxJobs")
+
 // RunGenericWorkerPoolStream executes jobs concurrently and streams results.
 // It guarantees 1:1 result mapping for every job ID.
+//
+// Duplicate job IDs reject the whole batch. Set AutoReindex when IDs come from
+// data you do not control: each job is then identified by its index in jobs,
+// so jobs[res.ID] recovers the original job (including its original ID).
+//
+// Memory: besides the jobs slice itself, a batch of n jobs holds a result channel
+// of n slots (unless ResultBuffer is set) and two ID sets of n entries, roughly
+// 150 bytes per job plus the size of Result[R]; AutoReindex adds a copy of jobs.
+// Set MaxJobs to reject oversized batches before this is allocated.
 func RunGenericWorkerPoolStream[T any, R any](
 	ctx context.Context,
 	jobs []Job[T],
@@ -40,6 +107,30 @@ func RunGenericWorkerPoolStream[T any, R any](
 	globalSemaphore chan struct{},
 	cfg WorkerPoolConfig,
 ) <-chan Result[R] {
+	return RunGenericWorkerPoolStreamIndexed(ctx, jobs, ignoreIndex(workerFunc), globalSemaphore, cfg)
+}
+
+// RunGenericWorkerPoolStreamIndexed behaves like RunGenericWorkerPoolStream but also
+// passes workerFunc the index (0..NumWorkers-1) of the goroutine running the job.
+// An index is owned by exactly one goroutine for the pool's lifetime, so a resource
+// stored at that index (DB connection, rate limiter, buffer) is never used by two
+// jobs at once and needs no locking. Size such slices with the effective worker
+// count: NumWorkers, or 2 when NumWorkers <= 0.
+//
+// Example:
+//
+//	conns := make([]*sql.Conn, cfg.NumWorkers)
+//	results := worker.RunGenericWorkerPoolStreamIndexed(ctx, jobs,
+//	    func(ctx context.Context, workerIndex int, row Row) (int64, error) {
+//	        return insertRow(ctx, conns[workerIndex], row)
+//	    }, nil, cfg)
+func RunGenericWorkerPoolStreamIndexed[T any, R any](
+	ctx context.Context,
+	jobs []Job[T],
+	workerFunc func(ctx context.Context, workerIndex int, data T) (R, error),
+	globalSemaphore chan struct{},
+	cfg WorkerPoolConfig,
+) <-chan Result[R] {
 
 	if len(jobs) == 0 {
 		outCh := make(chan Result[R])
@@ -47,11 +138,26 @@ func RunGenericWorkerPoolStream[T any, R any](
 		return outCh
 	}
 
+	// Reject oversized batches before allocating per-job state
+	if outCh := rejectOversized[T, R](jobs, cfg); outCh != nil {
+		return outCh
+	}
+
+	// Replace caller IDs with input positions; the caller's slice is not modified
+	if cfg.AutoReindex {
+		reindexed := make([]Job[T], len(jobs))
+		for i, job := range jobs {
+			job.ID = i
+			reindexed[i] = job
+		}
+		jobs = reindexed
+	}
+
 	// Validate duplicate IDs
 	seenIDs := make(map[int]bool, len(jobs))
 	for _, job := range jobs {
 		if seenIDs[job.ID] {
-			outCh := make(chan Result[R], len(jobs))
+			outCh := make(chan Result[R], resultBufferSize(cfg, len(jobs)))
 			go func() {
 				err := fmt.Errorf("duplicate job ID detected: %d (all jobs rejected)", job.ID)
 				for _, j := range jobs {
@@ -67,7 +173,7 @@ func RunGenericWorkerPoolStream[T any, R any](
 	// Check parent context
 	select {
 	case <-ctx.Done():
-		outCh := make(chan Result[R], len(jobs))
+		outCh := make(chan Result[R], resultBufferSize(cfg, len(jobs)))
 		go func() {
 			for _, job := range jobs {
 				outCh <- Result[R]{ID: job.ID, Err: ErrSkipped}
@@ -78,7 +184,99 @@ func RunGenericWorkerPoolStream[T any, R any](
 	default:
 	}
 
-	// Apply configuration defaults
+	cfg = withDefaults(cfg)
+
+	outCh := make(chan Result[R], resultBufferSize(cfg, len(jobs)))
+	jobCh := make(chan Job[T])
+
+	poolCtx, cancelPool := context.WithTimeout(ctx, cfg.GlobalTimeout)
+
+	var cancelOnce sync.Once
+	safeCancelPool := func() {
+		cancelOnce.Do(func() {
+			cancelPool()
+		})
+	}
+
+	var feederWG sync.WaitGroup
+	sentResults := &sync.Map{}
+
+	sendResult := func(result Result[R]) {
+		if _, alreadySent := sentResults.LoadOrStore(result.ID, true); !alreadySent {
+			outCh <- result
+		}
+	}
+
+	workerWG := runWorkers(poolCtx, jobCh, workerFunc, globalSemaphore, cfg, sendResult, safeCancelPool)
+
+	// Feeder
+	feederWG.Add(1)
+	go func() {
+		defer feederWG.Done()
+		defer close(jobCh)
+
+		for _, job := range jobs {
+			select {
+			case jobCh <- job:
+			case <-poolCtx.Done():
+				sendResult(Result[R]{ID: job.ID, Err: ErrSkipped})
+			}
+		}
+	}()
+
+	// Finalizer
+	go func() {
+		feederWG.Wait()
+		workerWG.Wait()
+		cancelPool() // Ensure cleanup
+		close(outCh)
+	}()
+
+	return outCh
+}
+
+// rejectOversized returns a stream failing every job with ErrTooManyJobs when
+// jobs exceeds cfg.MaxJobs, or nil if the batch may run. The buffer is bounded
+// by MaxJobs so rejecting a huge batch stays cheap; keep draining results.
+func rejectOversized[T any, R any](jobs []Job[T], cfg WorkerPoolConfig) <-chan Result[R] {
+	if cfg.MaxJobs <= 0 || len(jobs) <= cfg.MaxJobs {
+		return nil
+	}
+
+	err := fmt.Errorf("%w: %d jobs, limit %d", ErrTooManyJobs, len(jobs), cfg.MaxJobs)
+	outCh := make(chan Result[R], resultBufferSize(cfg, cfg.MaxJobs))
+	go func() {
+		for i, job := range jobs {
+			id := job.ID
+			if cfg.AutoReindex {
+				id = i
+			}
+			outCh <- Result[R]{ID: id, Err: err}
+		}
+		close(outCh)
+	}()
+	return outCh
+}
+
+// ignoreIndex adapts a plain worker function to the indexed form used internally.
+func ignoreIndex[T any, R any](workerFunc func(context.Context, T) (R, error)) func(context.Context, int, T) (R, error) {
+	return func(ctx context.Context, _ int, data T) (R, error) {
+		return workerFunc(ctx, data)
+	}
+}
+
+// resultBufferSize returns the result channel capacity for a batch of n jobs.
+// By default every result fits without blocking; ResultBuffer lowers that bound
+// so memory stays flat for huge batches while the consumer keeps up.
+func resultBufferSize(cfg WorkerPoolConfig, n int) int {
+	if cfg.ResultBuffer > 0 && cfg.ResultBuffer < n {
+		return cfg.ResultBuffer
+	}
+	return n
+}
+
+// withDefaults applies the pool configuration defaults.
+func withDefaults(cfg WorkerPoolConfig) WorkerPoolConfig {
 	if cfg.NumWorkers <= 0 {
 		cfg.NumWorkers = 2
 	}
@@ -100,29 +298,24 @@ func RunGenericWorkerPoolStream[T any, R any](
 		cfg.GlobalTimeout = cfg.WorkerTimeout * 2
 	}
 
-	outCh := make(chan Result[R], len(jobs))
-	jobCh := make(chan Job[T])
-
-	poolCtx, cancelPool := context.WithTimeout(ctx, cfg.GlobalTimeout)
-
-	var cancelOnce sync.Once
-	safeCancelPool := func() {
-		cancelOnce.Do(func() {
-			cancelPool()
-		})
-	}
+	return cfg
+}
 
+// runWorkers starts cfg.NumWorkers goroutines that consume jobCh until it is closed.
+// Every job read from jobCh yields exactly one sendResult call, and workerFunc
+// receives the index of the goroutine running it.
+// The returned WaitGroup is done once all workers have exited.
+func runWorkers[T any, R any](
+	poolCtx context.Context,
+	jobCh <-chan Job[T],
+	workerFunc func(context.Context, int, T) (R, error),
+	globalSemaphore chan struct{},
+	cfg WorkerPoolConfig,
+	sendResult func(Result[R]),
+	safeCancelPool func(),
+) *sync.WaitGroup {
 	var workerWG sync.WaitGroup
-	var feederWG sync.WaitGroup
-	sentResults := &sync.Map{}
-
-	sendResult := func(result Result[R]) {
-		if _, alreadySent := sentResults.LoadOrStore(result.ID, true); !alreadySent {
-			outCh <- result
-		}
-	}
 
-	// Worker goroutines
 	workerWG.Add(cfg.NumWorkers)
 	for i := 0; i < cfg.NumWorkers; i++ {
 		go func() {
@@ -152,19 +345,46 @@ func RunGenericWorkerPoolStream[T any, R any](
 						defer func() { <-globalSemaphore }()
 					}
 
+					// Re-check after acquiring: select picks randomly when the token and
+					// cancellation are ready together, so never start work on a dead pool
+					if poolCtx.Err() != nil {
+						sendResult(Result[R]{ID: job.ID, Err: ErrSkipped})
+						return
+					}
+
+					start := time.Now()
 					defer func() {
 						if r := recover(); r != nil {
-							sendResult(Result[R]{ID: job.ID, Err: fmt.Errorf("panic: %v", r)})
-							if cfg.StopOnError {
+							if cfg.OnPanic != nil {
+								cfg.OnPanic(job.ID, r, debug.Stack())
+							}
+							err := fmt.Errorf("panic: %v", r)
+							observeEnd(cfg.Observer, job.ID, time.Since(start), err)
+							sendResult(Result[R]{ID: job.ID, Err: err})
+							if cfg.StopOnPanic {
 								safeCancelPool()
 							}
 						}
 					}()
 
-					taskCtx, cancel := context.WithTimeout(poolCtx, cfg.WorkerTimeout)
+					// A panicking observer fails only this job, without counting as a job panic
+					if err := observeStart(cfg.Observer, job.ID); err != nil {
+						sendResult(Result[R]{ID: job.ID, Err: err})
+						return
+					}
+
+					// Per-job override, otherwise the pool default
+					timeout := cfg.WorkerTimeout
+					if job.Timeout > 0 {
+						timeout = job.Timeout
+					}
+
+					taskCtx, cancel := context.WithTimeout(poolCtx, timeout)
 					defer cancel()
 
-					res, err := workerFunc(taskCtx, job.Data)
+					res, err := workerFunc(taskCtx, i, job.Data)
+
+					observeEnd(cfg.Observer, job.ID, time.Since(start), err)
 
 					if err != nil && cfg.StopOnError {
 						safeCancelPool()
@@ -176,28 +396,5 @@ func RunGenericWorkerPoolStream[T any, R any](
 		}()
 	}
 
-	// Feeder
-	feederWG.Add(1)
-	go func() {
-		defer feederWG.Done()
-		defer close(jobCh)
-
-		for _, job := range jobs {
-			select {
-			case jobCh <- job:
-			case <-poolCtx.Done():
-				sendResult(Result[R]{ID: job.ID, Err: ErrSkipped})
-			}
-		}
-	}()
-
-	// Finalizer
-	go func() {
-		feederWG.Wait()
-		workerWG.Wait()
-		cancelPool() // Ensure cleanup
-		close(outCh)
-	}()
-
-	return outCh
+	return &workerWG
 }
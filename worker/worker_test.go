@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -303,6 +304,57 @@ func TestStopOnError(t *testing.T) {
 	t.Logf("Processed: %d, Errors: %d, Skipped: %d", processedCount, errorCount, skippedCount)
 }
 
+// TestStopOnPanic verifies StopOnError and StopOnPanic act independently:
+// job 3 panics and job 6 returns an error, on a single worker in ID order
+func TestStopOnPanic(t *testing.T) {
+	jobs := make([]Job[int], 10)
+	for i := range jobs {
+		jobs[i] = Job[int]{ID: i + 1, Data: i + 1}
+	}
+
+	workerFunc := func(ctx context.Context, n int) (int, error) {
+		switch n {
+		case 3:
+			panic("unexpected nil")
+		case 6:
+			return 0, errors.New("validation failed")
+		}
+		return n, nil
+	}
+
+	tests := []struct {
+		stopOnError bool
+		stopOnPanic bool
+		stoppedAt   int // last job that ran; 0 = nothing skipped
+	}{
+		{false, false, 0},
+		{true, false, 6}, // the panic is tolerated, the error stops
+		{false, true, 3}, // the panic stops
+		{true, true, 3},  // the panic comes first
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("StopOnError=%v/StopOnPanic=%v", tt.stopOnError, tt.stopOnPanic), func(t *testing.T) {
+			results := RunGenericWorkerPoolStream(context.Background(), jobs, workerFunc, nil, WorkerPoolConfig{
+				NumWorkers:  1,
+				StopOnError: tt.stopOnError,
+				StopOnPanic: tt.stopOnPanic,
+			})
+
+			for res := range results {
+				skipped := errors.Is(res.Err, ErrSkipped)
+				wantSkipped := tt.stoppedAt > 0 && res.ID > tt.stoppedAt
+				if skipped != wantSkipped {
+					t.Errorf("Job %d: skipped=%v, want %v (err: %v)", res.ID, skipped, wantSkipped, res.Err)
+				}
+				if res.ID == 3 && (res.Err == nil || !strings.HasPrefix(res.Err.Error(), "panic: ")) {
+					t.Errorf("Expected panic error for job 3, got %v", res.Err)
+				}
+			}
+		})
+	}
+}
+
 // TestGlobalTimeout tests global timeout
 func TestGlobalTimeout(t *testing.T) {
 	jobs := []Job[int]{
@@ -414,6 +466,268 @@ func TestWorkerTimeout(t *testing.T) {
 	t.Logf("Timeouts: %d, Success: %d", timeoutCount, successCount)
 }
 
+// TestOnPanic verifies the panic handler receives the value and stack
+// while the error result is still emitted
+func TestOnPanic(t *testing.T) {
+	var gotID int32
+	var gotValue atomic.Value
+	var gotStack atomic.Value
+
+	cfg := WorkerPoolConfig{
+		NumWorkers: 2,
+		OnPanic: func(id int, recovered any, stack []byte) {
+			atomic.StoreInt32(&gotID, int32(id))
+			gotValue.Store(recovered)
+			gotStack.Store(string(stack))
+		},
+	}
+
+	jobs := []Job[int]{{ID: 1, Data: 1}, {ID: 7, Data: 0}}
+	results := RunGenericWorkerPoolStream(context.Background(), jobs, func(ctx context.Context, n int) (int, error) {
+		return 10 / n, nil // integer divide by zero panics for ID 7
+	}, nil, cfg)
+
+	for res := range results {
+		if res.ID == 7 && (res.Err == nil || !strings.HasPrefix(res.Err.Error(), "panic: ")) {
+			t.Errorf("Expected panic error result, got %v", res.Err)
+		}
+	}
+
+	if atomic.LoadInt32(&gotID) != 7 {
+		t.Errorf("Expected OnPanic for ID 7, got %d", gotID)
+	}
+	if _, ok := gotValue.Load().(error); !ok {
+		t.Errorf("Expected runtime error as recovered value, got %v", gotValue.Load())
+	}
+	if stack, _ := gotStack.Load().(string); !strings.Contains(stack, "worker.TestOnPanic") {
+		t.Errorf("Expected stack to include the panicking function, got:\n%s", stack)
+	}
+}
+
+// TestPerJobTimeout tests Job.Timeout overriding WorkerTimeout
+func TestPerJobTimeout(t *testing.T) {
+	jobs := []Job[int]{
+		{ID: 1, Data: 100}, // pool default: times out
+		{ID: 2, Data: 200, Timeout: 500 * time.Millisecond}, // longer override: succeeds
+		{ID: 3, Data: 300, Timeout: 10 * time.Millisecond},  // shorter override: times out
+	}
+
+	workerFunc := func(ctx context.Context, data int) (string, error) {
+		select {
+		case <-time.After(150 * time.Millisecond):
+			return fmt.Sprintf("result-%d", data), nil
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+
+	results := RunGenericWorkerPoolStream(
+		context.Background(),
+		jobs,
+		workerFunc,
+		nil,
+		WorkerPoolConfig{
+			NumWorkers:    3,
+			WorkerTimeout: 50 * time.Millisecond,
+			GlobalTimeout: 2 * time.Second,
+		},
+	)
+
+	errs := make(map[int]error)
+	for res := range results {
+		errs[res.ID] = res.Err
+	}
+
+	if !errors.Is(errs[1], context.DeadlineExceeded) {
+		t.Errorf("Job 1: expected pool timeout, got %v", errs[1])
+	}
+	if errs[2] != nil {
+		t.Errorf("Job 2: expected success with longer timeout, got %v", errs[2])
+	}
+	if !errors.Is(errs[3], context.DeadlineExceeded) {
+		t.Errorf("Job 3: expected short timeout, got %v", errs[3])
+	}
+}
+
+// TestAutoReindex verifies duplicate IDs are replaced by input positions
+func TestAutoReindex(t *testing.T) {
+	// Upstream IDs with duplicates would normally reject the batch
+	jobs := []Job[string]{
+		{ID: 5, Data: "a"},
+		{ID: 5, Data: "b"},
+		{ID: 9, Data: "c"},
+	}
+
+	results := RunGenericWorkerPoolStream(context.Background(), jobs, func(ctx context.Context, s string) (string, error) {
+		return s + "!", nil
+	}, nil, WorkerPoolConfig{NumWorkers: 2, AutoReindex: true})
+
+	seen := make(map[int]bool)
+	for res := range results {
+		if res.Err != nil {
+			t.Fatalf("Unexpected error: %v", res.Err)
+		}
+		// Result.ID is the input position
+		if res.Value != jobs[res.ID].Data+"!" {
+			t.Errorf("ID %d: expected %q, got %q", res.ID, jobs[res.ID].Data+"!", res.Value)
+		}
+		seen[res.ID] = true
+	}
+
+	if len(seen) != len(jobs) {
+		t.Errorf("Expected %d distinct results, got %d", len(jobs), len(seen))
+	}
+	// Caller's slice keeps its original IDs
+	if jobs[0].ID != 5 || jobs[2].ID != 9 {
+		t.Errorf("Input jobs were modified: %+v", jobs)
+	}
+}
+
+// TestMaxJobs verifies oversized batches are rejected without running any job
+func TestMaxJobs(t *testing.T) {
+	jobs := make([]Job[int], 10)
+	for i := range jobs {
+		jobs[i] = Job[int]{ID: i + 100, Data: i}
+	}
+
+	var ran atomic.Int32
+	work := func(ctx context.Context, n int) (int, error) {
+		ran.Add(1)
+		return n, nil
+	}
+
+	results := RunGenericWorkerPoolStream(context.Background(), jobs, work, nil, WorkerPoolConfig{MaxJobs: 5})
+	if cap(results) > 5 {
+		t.Errorf("Expected buffer bounded by MaxJobs, got %d", cap(results))
+	}
+
+	count := 0
+	for res := range results {
+		count++
+		if !errors.Is(res.Err, ErrTooManyJobs) {
+			t.Errorf("ID %d: expected ErrTooManyJobs, got %v", res.ID, res.Err)
+		}
+		if res.ID < 100 {
+			t.Errorf("Expected original job IDs, got %d", res.ID)
+		}
+	}
+	if count != len(jobs) {
+		t.Errorf("Expected %d results, got %d", len(jobs), count)
+	}
+	if ran.Load() != 0 {
+		t.Errorf("Expected no job to run, %d ran", ran.Load())
+	}
+
+	// At the limit the batch runs normally
+	for res := range RunGenericWorkerPoolStream(context.Background(), jobs, work, nil, WorkerPoolConfig{MaxJobs: 10}) {
+		if res.Err != nil {
+			t.Errorf("Unexpected error: %v", res.Err)
+		}
+	}
+}
+
+// TestResultBuffer verifies a small result buffer still delivers every result
+func TestResultBuffer(t *testing.T) {
+	const numJobs = 200
+
+	jobs := make([]Job[int], numJobs)
+	for i := 0; i < numJobs; i++ {
+		jobs[i] = Job[int]{ID: i, Data: i}
+	}
+
+	workerFunc := func(ctx context.Context, data int) (int, error) {
+		if data == 50 {
+			return 0, errors.New("intentional error")
+		}
+		return data * 2, nil
+	}
+
+	for _, stopOnError := range []bool{false, true} {
+		t.Run(fmt.Sprintf("StopOnError=%v", stopOnError), func(t *testing.T) {
+			results := RunGenericWorkerPoolStream(
+				context.Background(),
+				jobs,
+				workerFunc,
+				nil,
+				WorkerPoolConfig{
+					NumWorkers:   4,
+					StopOnError:  stopOnError,
+					ResultBuffer: 1,
+				},
+			)
+
+			if cap(results) != 1 {
+				t.Errorf("Expected buffer capacity 1, got %d", cap(results))
+			}
+
+			seen := make(map[int]bool)
+			for res := range results {
+				time.Sleep(10 * time.Microsecond) // Slow consumer
+				seen[res.ID] = true
+			}
+
+			if len(seen) != numJobs {
+				t.Errorf("Expected %d results, got %d", numJobs, len(seen))
+			}
+		})
+	}
+
+	// Duplicate-ID path honours the cap too
+	dup := []Job[int]{{ID: 1}, {ID: 1}, {ID: 2}}
+	results := RunGenericWorkerPoolStream(context.Background(), dup, workerFunc, nil, WorkerPoolConfig{ResultBuffer: 1})
+	count := 0
+	for range results {
+		count++
+	}
+	if count != len(dup) {
+		t.Errorf("Expected %d duplicate-rejection results, got %d", len(dup), count)
+	}
+}
+
+// TestSemaphoreCancelledAfterAcquire verifies no work starts once the pool is
+// cancelled, even if a worker obtains the semaphore token at the same moment
+func TestSemaphoreCancelledAfterAcquire(t *testing.T) {
+	for round := 0; round < 20; round++ {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		// Saturate the semaphore so every worker blocks on acquire
+		sem := make(chan struct{}, 1)
+		sem <- struct{}{}
+
+		jobs := []Job[int]{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}
+		var ran int32
+
+		workerFunc := func(ctx context.Context, data int) (int, error) {
+			atomic.AddInt32(&ran, 1)
+			return data, nil
+		}
+
+		results := RunGenericWorkerPoolStream(ctx, jobs, workerFunc, sem, WorkerPoolConfig{NumWorkers: 4})
+
+		// Let every worker reach the semaphore select
+		time.Sleep(20 * time.Millisecond)
+
+		// Hand the token to a waiting worker and cancel right away
+		<-sem
+		cancel()
+
+		count := 0
+		for res := range results {
+			count++
+			if res.Err != ErrSkipped {
+				t.Errorf("Round %d: expected ErrSkipped for job %d, got %v", round, res.ID, res.Err)
+			}
+		}
+
+		if count != len(jobs) {
+			t.Errorf("Round %d: expected %d results, got %d", round, len(jobs), count)
+		}
+		if n := atomic.LoadInt32(&ran); n != 0 {
+			t.Fatalf("Round %d: %d jobs ran after cancellation", round, n)
+		}
+	}
+}
+
 // TestNoDuplicateResults verifies no duplicate results even under high concurrency
 func TestNoDuplicateResults(t *testing.T) {
 	const numJobs = 100
@@ -584,6 +898,173 @@ func TestLargeDatasetTimeout(t *testing.T) {
 	}
 }
 
+// countingObserver records job lifecycle events for assertions
+type countingObserver struct {
+	started  int32
+	ended    int32
+	failed   int32
+	totalDur int64
+}
+
+func (o *countingObserver) OnJobStart(id int) {
+	atomic.AddInt32(&o.started, 1)
+}
+
+func (o *countingObserver) OnJobEnd(id int, d time.Duration, err error) {
+	atomic.AddInt32(&o.ended, 1)
+	atomic.AddInt64(&o.totalDur, int64(d))
+	if err != nil {
+		atomic.AddInt32(&o.failed, 1)
+	}
+}
+
+// TestObserver verifies hooks fire once per executed job, including panics
+func TestObserver(t *testing.T) {
+	jobs := []Job[int]{
+		{ID: 1, Data: 100},
+		{ID: 2, Data: 200}, // This will error
+		{ID: 3, Data: 300}, // This will panic
+		{ID: 4, Data: 400},
+	}
+
+	workerFunc := func(ctx context.Context, data int) (string, error) {
+		time.Sleep(5 * time.Millisecond)
+		switch data {
+		case 200:
+			return "", errors.New("intentional error")
+		case 300:
+			panic("intentional panic")
+		}
+		return fmt.Sprintf("result-%d", data), nil
+	}
+
+	obs := &countingObserver{}
+	results := RunGenericWorkerPoolStream(
+		context.Background(),
+		jobs,
+		workerFunc,
+		nil,
+		WorkerPoolConfig{NumWorkers: 2, Observer: obs},
+	)
+
+	count := 0
+	for range results {
+		count++
+	}
+
+	if count != len(jobs) {
+		t.Errorf("Expected %d results, got %d", len(jobs), count)
+	}
+	if obs.started != int32(len(jobs)) {
+		t.Errorf("Expected %d OnJobStart calls, got %d", len(jobs), obs.started)
+	}
+	if obs.ended != int32(len(jobs)) {
+		t.Errorf("Expected %d OnJobEnd calls, got %d", len(jobs), obs.ended)
+	}
+	if obs.failed != 2 {
+		t.Errorf("Expected 2 failed jobs reported, got %d", obs.failed)
+	}
+	if time.Duration(obs.totalDur) < time.Duration(len(jobs))*5*time.Millisecond {
+		t.Errorf("Expected durations to cover job work, got %v", time.Duration(obs.totalDur))
+	}
+}
+
+// panickingObserver panics in OnJobStart for panicID and in OnJobEnd for endPanicID
+type panickingObserver struct {
+	countingObserver
+	panicID    int
+	endPanicID int
+}
+
+func (o *panickingObserver) OnJobEnd(id int, d time.Duration, err error) {
+	o.countingObserver.OnJobEnd(id, d, err)
+	if id == o.endPanicID {
+		panic("observer end exploded")
+	}
+}
+
+func (o *panickingObserver) OnJobStart(id int) {
+	if id == o.panicID {
+		panic("observer exploded")
+	}
+	o.countingObserver.OnJobStart(id)
+}
+
+// TestObserverPanic verifies a panicking observer fails only its job instead of crashing
+func TestObserverPanic(t *testing.T) {
+	jobs := []Job[int]{{ID: 1, Data: 1}, {ID: 2, Data: 2}, {ID: 3, Data: 3}}
+	var ran int32
+	workerFunc := func(ctx context.Context, data int) (int, error) {
+		atomic.AddInt32(&ran, 1)
+		return data, nil
+	}
+
+	obs := &panickingObserver{panicID: 2, endPanicID: -1}
+	var onPanic int32
+	sem := make(chan struct{}, 1)
+	results := RunGenericWorkerPoolStream(context.Background(), jobs, workerFunc, sem, WorkerPoolConfig{
+		NumWorkers:  2,
+		Observer:    obs,
+		StopOnPanic: true, // an observer panic must not cancel the pool
+		OnPanic:     func(int, any, []byte) { atomic.AddInt32(&onPanic, 1) },
+	})
+
+	count := 0
+	for res := range results {
+		count++
+		if res.ID == 2 {
+			if res.Err == nil || !strings.Contains(res.Err.Error(), "observer exploded") {
+				t.Errorf("Expected job 2 to fail with the observer panic, got %v", res.Err)
+			}
+			continue
+		}
+		if res.Err != nil {
+			t.Errorf("Job %d: unexpected error %v", res.ID, res.Err)
+		}
+	}
+
+	if count != len(jobs) {
+		t.Errorf("Expected %d results, got %d", len(jobs), count)
+	}
+	if ran != 2 {
+		t.Errorf("Expected workerFunc to run for 2 jobs, got %d", ran)
+	}
+	if len(sem) != 0 {
+		t.Errorf("Expected semaphore to be released, %d slots held", len(sem))
+	}
+	if onPanic != 0 {
+		t.Errorf("Expected OnPanic not to fire for observer panics, got %d calls", onPanic)
+	}
+}
+
+// TestObserverEndPanic verifies a panic in OnJobEnd keeps the job's result and
+// reports the job exactly once
+func TestObserverEndPanic(t *testing.T) {
+	jobs := []Job[int]{{ID: 1, Data: 10}, {ID: 2, Data: 20}}
+	obs := &panickingObserver{panicID: -1, endPanicID: 1}
+	var onPanic int32
+	results := RunGenericWorkerPoolStream(context.Background(), jobs, func(ctx context.Context, n int) (int, error) {
+		return n, nil
+	}, nil, WorkerPoolConfig{
+		NumWorkers:  1,
+		Observer:    obs,
+		StopOnPanic: true,
+		OnPanic:     func(int, any, []byte) { atomic.AddInt32(&onPanic, 1) },
+	})
+
+	for res := range results {
+		if res.Err != nil || res.Value != res.ID*10 {
+			t.Errorf("Expected job %d to keep its result, got %+v", res.ID, res)
+		}
+	}
+	if obs.ended != int32(len(jobs)) {
+		t.Errorf("Expected %d OnJobEnd calls, got %d", len(jobs), obs.ended)
+	}
+	if onPanic != 0 {
+		t.Errorf("Expected OnPanic not to fire for observer panics, got %d calls", onPanic)
+	}
+}
+
 // BenchmarkWorkerPool benchmarks worker pool performance
 func BenchmarkWorkerPool(b *testing.B) {
 	jobs := make([]Job[int], 100)
@@ -611,3 +1092,53 @@ func BenchmarkWorkerPool(b *testing.B) {
 		}
 	}
 }
+
+// TestIndexedWorkers verifies worker indexes are in range and never used by two jobs at once
+func TestIndexedWorkers(t *testing.T) {
+	const numWorkers = 4
+	jobs := make([]Job[int], 100)
+	for i := range jobs {
+		jobs[i] = Job[int]{ID: i, Data: i}
+	}
+
+	// One in-use flag per worker index; a concurrent second user trips it
+	var inUse [numWorkers]atomic.Bool
+	var seen [numWorkers]atomic.Int32
+	workerFunc := func(ctx context.Context, workerIndex int, data int) (int, error) {
+		if workerIndex < 0 || workerIndex >= numWorkers {
+			return 0, fmt.Errorf("worker index %d out of range", workerIndex)
+		}
+		if !inUse[workerIndex].CompareAndSwap(false, true) {
+			return 0, fmt.Errorf("worker index %d used concurrently", workerIndex)
+		}
+		defer inUse[workerIndex].Store(false)
+		seen[workerIndex].Add(1)
+		time.Sleep(time.Millisecond)
+		return data * 2, nil
+	}
+
+	results := RunGenericWorkerPoolStreamIndexed(context.Background(), jobs, workerFunc, nil, WorkerPoolConfig{NumWorkers: numWorkers})
+
+	count := 0
+	for res := range results {
+		count++
+		if res.Err != nil {
+			t.Errorf("Job %d: %v", res.ID, res.Err)
+			continue
+		}
+		if res.Value != res.ID*2 {
+			t.Errorf("Job %d: expected %d, got %d", res.ID, res.ID*2, res.Value)
+		}
+	}
+	if count != len(jobs) {
+		t.Errorf("Expected %d results, got %d", len(jobs), count)
+	}
+
+	total := int32(0)
+	for i := range seen {
+		total += seen[i].Load()
+	}
+	if total != int32(len(jobs)) {
+		t.Errorf("Expected %d indexed calls, got %d", len(jobs), total)
+	}
+}